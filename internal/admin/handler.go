@@ -0,0 +1,327 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	a "github.com/morphy76/lang-actor/pkg/admin"
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+const (
+	defaultEventBuffer = 256
+	rootNodesPattern   = "graph://edge/root/*"
+)
+
+var staticOperatorAssertion c.Addressable = (*operator)(nil)
+
+// operator is the sender of the messages sent through the admin endpoint.
+type operator struct {
+	address url.URL
+}
+
+// Address returns the address of the admin endpoint.
+func (o *operator) Address() url.URL {
+	return o.address
+}
+
+type handler struct {
+	config   a.Config
+	operator *operator
+}
+
+// NewHandler creates the admin HTTP handler of an actor system, to be mounted on a mux.
+//
+// The routes are relative to the mount point, e.g. with http.StripPrefix:
+//   - GET /actors: the actor tree, as JSON or as text with ?format=tree;
+//   - GET /actors/stats?address=...: the figures of an actor and of its children;
+//   - POST /actors/send: sends the admin.SendRequest message;
+//   - POST /actors/stop?address=... and POST /actors/restart?address=...: operate an actor;
+//   - GET /graphs: the graphs and their nodes;
+//   - POST /graphs/run: runs the graph of the admin.RunRequest from its root nodes;
+//   - GET /events: the lifecycle events, as server-sent events.
+func NewHandler(config a.Config) (http.Handler, error) {
+	if config.System == nil {
+		return nil, errors.New("missing actor system")
+	}
+	if config.EventBuffer <= 0 {
+		config.EventBuffer = defaultEventBuffer
+	}
+
+	rv := &handler{
+		config:   config,
+		operator: &operator{address: url.URL{Scheme: "admin", Host: config.System.Name()}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /actors", rv.listActors)
+	mux.HandleFunc("GET /actors/stats", rv.actorStats)
+	mux.HandleFunc("POST /actors/send", rv.send)
+	mux.HandleFunc("POST /actors/stop", rv.stop)
+	mux.HandleFunc("POST /actors/restart", rv.restart)
+	mux.HandleFunc("GET /graphs", rv.listGraphs)
+	mux.HandleFunc("POST /graphs/run", rv.runGraph)
+	mux.HandleFunc("GET /events", rv.events)
+	return mux, nil
+}
+
+func (h *handler) listActors(w http.ResponseWriter, req *http.Request) {
+	tree := framework.Inspect(h.config.System.Roots()...)
+	if req.URL.Query().Get("format") == "tree" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, tree.String())
+		return
+	}
+	writeJSON(w, http.StatusOK, tree)
+}
+
+func (h *handler) actorStats(w http.ResponseWriter, req *http.Request) {
+	ref, status, err := h.lookup(req.URL.Query().Get("address"))
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	actorRef, ok := ref.(f.ActorRef)
+	if !ok {
+		http.Error(w, fmt.Sprintf("[%T] is not an actor", ref), http.StatusUnprocessableEntity)
+		return
+	}
+	writeJSON(w, http.StatusOK, framework.Inspect(actorRef)[0])
+}
+
+func (h *handler) send(w http.ResponseWriter, req *http.Request) {
+	request := a.SendRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ref, status, err := h.lookup(request.Address)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	transport, ok := ref.(c.Transport)
+	if !ok {
+		http.Error(w, fmt.Sprintf("[%s] cannot receive messages", request.Address), http.StatusUnprocessableEntity)
+		return
+	}
+
+	payload, err := h.decode(request.Type, request.Payload)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if err := transport.Deliver(payload, h.operator); err != nil {
+		http.Error(w, fmt.Sprintf("delivery failed: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *handler) stop(w http.ResponseWriter, req *http.Request) {
+	ref, status, err := h.lookup(req.URL.Query().Get("address"))
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	controllable, ok := ref.(f.Controllable)
+	if !ok {
+		http.Error(w, fmt.Sprintf("[%T] cannot be stopped", ref), http.StatusUnprocessableEntity)
+		return
+	}
+	if _, err := controllable.Stop(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *handler) restart(w http.ResponseWriter, req *http.Request) {
+	ref, status, err := h.lookup(req.URL.Query().Get("address"))
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	restartable, ok := ref.(f.Restartable)
+	if !ok {
+		http.Error(w, fmt.Sprintf("[%T] cannot be restarted", ref), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := restartable.Restart(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *handler) listGraphs(w http.ResponseWriter, _ *http.Request) {
+	rv := make([]a.GraphInfo, 0, len(h.config.Graphs))
+	for name, graph := range h.config.Graphs {
+		info := a.GraphInfo{Name: name, Nodes: make([]string, 0)}
+		if selection, err := graph.Select("*://**"); err == nil {
+			for _, node := range selection.Members() {
+				address := node.Address()
+				info.Nodes = append(info.Nodes, address.String())
+			}
+		}
+		rv = append(rv, info)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].Name < rv[j].Name
+	})
+	writeJSON(w, http.StatusOK, rv)
+}
+
+func (h *handler) runGraph(w http.ResponseWriter, req *http.Request) {
+	request := a.RunRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	graph, found := h.config.Graphs[request.Graph]
+	if !found {
+		http.Error(w, fmt.Sprintf("graph [%s] not found", request.Graph), http.StatusNotFound)
+		return
+	}
+	roots, err := graph.Select(rootNodesPattern)
+	if err != nil || len(roots.Members()) == 0 {
+		http.Error(w, fmt.Sprintf("graph [%s] has no root node", request.Graph), http.StatusUnprocessableEntity)
+		return
+	}
+
+	payload, err := h.decode(request.Type, request.Payload)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	for _, root := range roots.Members() {
+		acceptor, ok := root.(interface{ Accept(msg any) error })
+		if !ok {
+			continue
+		}
+		if err := acceptor.Accept(payload); err != nil {
+			http.Error(w, fmt.Sprintf("graph [%s] not run: %v", request.Graph, err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *handler) events(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel := h.config.System.Subscribe(h.config.EventBuffer)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			address := event.Address
+			streamed := a.Event{Kind: event.Kind.String(), Address: address.String(), Time: event.Time}
+			if event.Err != nil {
+				streamed.Error = event.Err.Error()
+			}
+			data, err := json.Marshal(streamed)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", streamed.Kind, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// lookup finds an actor of the system tree, or registered in the system address book.
+func (h *handler) lookup(rawAddress string) (c.Addressable, int, error) {
+	if rawAddress == "" {
+		return nil, http.StatusBadRequest, errors.New("missing address")
+	}
+	address, err := url.Parse(rawAddress)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid address [%s]: %w", rawAddress, err)
+	}
+
+	if found, ok := find(h.config.System.Roots(), *address); ok {
+		return found, http.StatusOK, nil
+	}
+	if found, ok := h.config.System.AddressBook().Resolve(*address); ok {
+		return found, http.StatusOK, nil
+	}
+	return nil, http.StatusNotFound, errors.Join(r.ErrorActorNotFound, fmt.Errorf("actor [%s] not found", rawAddress))
+}
+
+func find(refs []f.ActorRef, address url.URL) (f.ActorRef, bool) {
+	for _, ref := range refs {
+		if ref.Address() == address {
+			return ref, true
+		}
+		if withChildren, ok := ref.(interface{ Children() []f.ActorRef }); ok {
+			if found, ok := find(withChildren.Children(), address); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (h *handler) decode(messageType string, payload json.RawMessage) (any, error) {
+	if messageType == "" {
+		var rv any
+		if err := json.Unmarshal(payload, &rv); err != nil {
+			return nil, fmt.Errorf("invalid payload: %w", err)
+		}
+		return rv, nil
+	}
+
+	decoder, found := h.config.Decoders[messageType]
+	if !found {
+		return nil, errors.Join(a.ErrorUnknownMessageType, fmt.Errorf("no decoder for message type [%s]", messageType))
+	}
+	rv, err := decoder(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload of type [%s]: %w", messageType, err)
+	}
+	return rv, nil
+}
+
+func writeDecodeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, a.ErrorUnknownMessageType) {
+		status = http.StatusUnprocessableEntity
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(value)
+}
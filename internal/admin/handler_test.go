@@ -0,0 +1,219 @@
+package admin_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/admin"
+	a "github.com/morphy76/lang-actor/pkg/admin"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	g "github.com/morphy76/lang-actor/pkg/graph"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+const counterURI = "actor://admin-test/counter"
+
+type increment struct {
+	Amount int `json:"amount"`
+}
+
+type graphState struct{}
+
+func (s graphState) MergeChange(purpose any, value any) error {
+	return nil
+}
+
+func (s graphState) Unwrap() g.State {
+	return s
+}
+
+func (s graphState) ReadAttribute(name string) any {
+	return nil
+}
+
+// newCounter counts the increments, reporting each total to the probe, and fails on generic payloads.
+func newCounter(t *testing.T, system f.ActorSystem, probe testkit.TestProbe) f.Actor[int] {
+	address, err := url.Parse(counterURI)
+	assert.NilError(t, err)
+
+	counter, err := b.NewActor(*address, func(msg f.Message, self f.Actor[int]) (int, error) {
+		switch payload := msg.Payload().(type) {
+		case increment:
+			total := self.State() + payload.Amount
+			return total, probe.Deliver(total, self)
+		case map[string]any:
+			return self.State(), probe.Deliver(payload, self)
+		default:
+			return self.State(), errors.New("unsupported message")
+		}
+	}, 0, b.WithSystem(system))
+	assert.NilError(t, err)
+	return counter
+}
+
+func newServer(t *testing.T, config a.Config) *httptest.Server {
+	handler, err := admin.NewHandler(config)
+	assert.NilError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin", handler))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(t *testing.T, target string, body string) *http.Response {
+	response, err := http.Post(target, "application/json", strings.NewReader(body))
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		response.Body.Close()
+	})
+	return response
+}
+
+func TestAdminHandler(t *testing.T) {
+	t.Log("Admin handler test suite")
+
+	t.Run("Require an actor system", func(t *testing.T) {
+		t.Log("Should not create a handler without actor system")
+
+		_, err := admin.NewHandler(a.Config{})
+		assert.ErrorContains(t, err, "missing actor system")
+	})
+
+	t.Run("List the actors and the graphs", func(t *testing.T) {
+		t.Log("Should render the actor tree as JSON or text and list the graph nodes")
+
+		system := b.NewActorSystem("admin-list")
+		probe := testkit.NewTestProbe(t)
+		newCounter(t, system, probe)
+		graph, err := b.NewGraph(graphState{}, g.NoConfiguration{})
+		assert.NilError(t, err)
+		_, err = b.NewRootNode(graph)
+		assert.NilError(t, err)
+		server := newServer(t, a.Config{System: system, Graphs: map[string]g.Graph{"flow": graph}})
+
+		response, err := http.Get(server.URL + "/admin/actors")
+		assert.NilError(t, err)
+		defer response.Body.Close()
+		tree := f.ActorTree{}
+		assert.NilError(t, json.NewDecoder(response.Body).Decode(&tree))
+		assert.Equal(t, len(tree), 1)
+		assert.Equal(t, tree[0].Address, counterURI)
+
+		response, err = http.Get(server.URL + "/admin/actors?format=tree")
+		assert.NilError(t, err)
+		defer response.Body.Close()
+		line, err := bufio.NewReader(response.Body).ReadString('\n')
+		assert.NilError(t, err)
+		assert.Assert(t, strings.HasPrefix(line, counterURI+" [running]"))
+
+		response, err = http.Get(server.URL + "/admin/graphs")
+		assert.NilError(t, err)
+		defer response.Body.Close()
+		graphs := []a.GraphInfo{}
+		assert.NilError(t, json.NewDecoder(response.Body).Decode(&graphs))
+		assert.Equal(t, len(graphs), 1)
+		assert.Equal(t, graphs[0].Name, "flow")
+		assert.Equal(t, len(graphs[0].Nodes), 1)
+	})
+
+	t.Run("Operate an actor", func(t *testing.T) {
+		t.Log("Should send decoded messages, report the stats, restart and stop the actor")
+
+		system := b.NewActorSystem("admin-operate")
+		probe := testkit.NewTestProbe(t)
+		counter := newCounter(t, system, probe)
+		server := newServer(t, a.Config{
+			System:   system,
+			Decoders: map[string]a.Decoder{"increment": a.DecoderFor[increment]()},
+		})
+		query := "?address=" + url.QueryEscape(counterURI)
+
+		response := post(t, server.URL+"/admin/actors/send", `{"address":"`+counterURI+`","type":"increment","payload":{"amount":2}}`)
+		assert.Equal(t, response.StatusCode, http.StatusAccepted)
+		probe.ExpectMsg(2)
+
+		response = post(t, server.URL+"/admin/actors/send", `{"address":"`+counterURI+`","payload":{"hello":"world"}}`)
+		assert.Equal(t, response.StatusCode, http.StatusAccepted)
+		probe.ExpectMsg(map[string]any{"hello": "world"})
+
+		response = post(t, server.URL+"/admin/actors/send", `{"address":"`+counterURI+`","type":"unknown","payload":{}}`)
+		assert.Equal(t, response.StatusCode, http.StatusUnprocessableEntity)
+		response = post(t, server.URL+"/admin/actors/send", `{"address":"actor://admin-test/missing","payload":{}}`)
+		assert.Equal(t, response.StatusCode, http.StatusNotFound)
+
+		stats, err := http.Get(server.URL + "/admin/actors/stats" + query)
+		assert.NilError(t, err)
+		defer stats.Body.Close()
+		info := f.ActorInfo{}
+		assert.NilError(t, json.NewDecoder(stats.Body).Decode(&info))
+		assert.Equal(t, info.Processed, uint64(2))
+		assert.Equal(t, info.MailboxCapacity, 100)
+
+		response = post(t, server.URL+"/admin/actors/restart"+query, "")
+		assert.Equal(t, response.StatusCode, http.StatusAccepted)
+		response = post(t, server.URL+"/admin/actors/send", `{"address":"`+counterURI+`","type":"increment","payload":{"amount":1}}`)
+		assert.Equal(t, response.StatusCode, http.StatusAccepted)
+		probe.ExpectMsg(1)
+
+		probe.WatchActor(counter)
+		response = post(t, server.URL+"/admin/actors/stop"+query, "")
+		assert.Equal(t, response.StatusCode, http.StatusAccepted)
+		probe.ExpectTerminated(counter)
+	})
+
+	t.Run("Stream the lifecycle events", func(t *testing.T) {
+		t.Log("Should stream the lifecycle events as server-sent events")
+
+		system := b.NewActorSystem("admin-events")
+		server := newServer(t, a.Config{System: system})
+
+		response, err := http.Get(server.URL + "/admin/events")
+		assert.NilError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, response.Header.Get("Content-Type"), "text/event-stream")
+
+		probe := testkit.NewTestProbe(t)
+		counter := newCounter(t, system, probe)
+		assert.NilError(t, counter.Deliver("unsupported", nil))
+
+		reader := bufio.NewReader(response.Body)
+		received := make(chan a.Event, 2)
+		go func() {
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					close(received)
+					return
+				}
+				if data, found := strings.CutPrefix(line, "data: "); found {
+					event := a.Event{}
+					if json.Unmarshal([]byte(data), &event) == nil {
+						received <- event
+					}
+				}
+			}
+		}()
+
+		for _, expected := range []string{"started", "failed"} {
+			select {
+			case event := <-received:
+				assert.Equal(t, event.Kind, expected)
+				assert.Equal(t, event.Address, counterURI)
+			case <-time.After(testkit.DefaultTimeout):
+				t.Fatalf("no %s event", expected)
+			}
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	a "github.com/morphy76/lang-actor/pkg/admin"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// AddressEnv is the environment variable providing the default admin address.
+const AddressEnv = "LANG_ACTOR_ADDR"
+
+const defaultAddress = "http://localhost:9090"
+
+const usage = `Usage: lang-actor [-addr URL] <command> [arguments]
+
+Commands:
+  ls                                list the actors
+  tree                              print the actor tree
+  stat <address>                    print the figures of an actor
+  send [-type T] <address> <json>   send a JSON message to an actor
+  stop <address>                    stop an actor
+  restart <address>                 restart an actor
+  tail events                       follow the lifecycle events
+  graphs                            list the graphs
+  graphs run [-type T] <name> <json>
+                                    run a graph with a JSON message
+
+The admin address defaults to $` + AddressEnv + ` or ` + defaultAddress + `.
+`
+
+var errUsage = errors.New("invalid usage")
+
+type client struct {
+	ctx    context.Context
+	base   string
+	http   *http.Client
+	stdout io.Writer
+}
+
+// Run executes a command against the admin endpoint of a running actor system.
+//
+// It returns the exit code of the command: 0 on success, 1 on failure and 2 on invalid usage.
+func Run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lang-actor", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+	}
+	address := defaultAddress
+	if fromEnv := os.Getenv(AddressEnv); fromEnv != "" {
+		address = fromEnv
+	}
+	flags.StringVar(&address, "addr", address, "the admin address of the actor system")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	c := &client{
+		ctx:    ctx,
+		base:   strings.TrimSuffix(address, "/"),
+		http:   &http.Client{},
+		stdout: stdout,
+	}
+	err := c.dispatch(flags.Args())
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "%v\n\n%s", err, usage)
+		return 2
+	case errors.Is(err, context.Canceled):
+		return 0
+	default:
+		fmt.Fprintf(stderr, "lang-actor: %v\n", err)
+		return 1
+	}
+}
+
+func (c *client) dispatch(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing command", errUsage)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "ls":
+		return c.list()
+	case "tree":
+		return c.get("/actors?format=tree", c.stdout)
+	case "stat":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: stat needs an address", errUsage)
+		}
+		return c.get("/actors/stats?address="+url.QueryEscape(args[0]), c.stdout)
+	case "send":
+		messageType, args, err := typeFlag("send", args)
+		if err != nil {
+			return err
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("%w: send needs an address and a JSON message", errUsage)
+		}
+		return c.post("/actors/send", a.SendRequest{Address: args[0], Type: messageType, Payload: json.RawMessage(args[1])})
+	case "stop", "restart":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: %s needs an address", errUsage, command)
+		}
+		return c.post("/actors/"+command+"?address="+url.QueryEscape(args[0]), nil)
+	case "tail":
+		if len(args) != 1 || args[0] != "events" {
+			return fmt.Errorf("%w: tail supports only events", errUsage)
+		}
+		return c.tail()
+	case "graphs":
+		if len(args) == 0 {
+			return c.get("/graphs", c.stdout)
+		}
+		if args[0] != "run" {
+			return fmt.Errorf("%w: unknown graphs command [%s]", errUsage, args[0])
+		}
+		messageType, args, err := typeFlag("graphs run", args[1:])
+		if err != nil {
+			return err
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("%w: graphs run needs a graph name and a JSON message", errUsage)
+		}
+		return c.post("/graphs/run", a.RunRequest{Graph: args[0], Type: messageType, Payload: json.RawMessage(args[1])})
+	default:
+		return fmt.Errorf("%w: unknown command [%s]", errUsage, command)
+	}
+}
+
+func typeFlag(command string, args []string) (string, []string, error) {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	messageType := flags.String("type", "", "the message type known by the admin endpoint decoders")
+	if err := flags.Parse(args); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return *messageType, flags.Args(), nil
+}
+
+func (c *client) list() error {
+	buffer := &bytes.Buffer{}
+	if err := c.get("/actors", buffer); err != nil {
+		return err
+	}
+	tree := f.ActorTree{}
+	if err := json.Unmarshal(buffer.Bytes(), &tree); err != nil {
+		return fmt.Errorf("unexpected response: %w", err)
+	}
+
+	var walk func(infos []f.ActorInfo)
+	walk = func(infos []f.ActorInfo) {
+		for _, info := range infos {
+			fmt.Fprintf(c.stdout, "%s\t%s\n", info.Address, info.Status)
+			walk(info.Children)
+		}
+	}
+	walk(tree)
+	return nil
+}
+
+func (c *client) tail() error {
+	response, err := c.do(http.MethodGet, "/events", nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		data, found := strings.CutPrefix(scanner.Text(), "data: ")
+		if !found {
+			continue
+		}
+		event := a.Event{}
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("unexpected event: %w", err)
+		}
+		line := fmt.Sprintf("%s\t%-9s\t%s", event.Time.Format("2006-01-02T15:04:05.000Z07:00"), event.Kind, event.Address)
+		if event.Error != "" {
+			line += "\t" + event.Error
+		}
+		fmt.Fprintln(c.stdout, line)
+	}
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	return scanner.Err()
+}
+
+func (c *client) get(path string, out io.Writer) error {
+	response, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(out, response.Body)
+	return err
+}
+
+func (c *client) post(path string, body any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	response, err := c.do(http.MethodPost, path, reader)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	fmt.Fprintln(c.stdout, "accepted")
+	return nil
+}
+
+func (c *client) do(method string, path string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(c.ctx, method, c.base+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin address [%s]: %w", c.base, err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		defer response.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf("%s %s: %s: %s", method, path, response.Status, strings.TrimSpace(string(message)))
+	}
+	return response, nil
+}
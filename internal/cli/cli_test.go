@@ -0,0 +1,156 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/cli"
+	a "github.com/morphy76/lang-actor/pkg/admin"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	g "github.com/morphy76/lang-actor/pkg/graph"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+const echoURI = "actor://cli-test/echo"
+
+type graphState struct{}
+
+func (s graphState) MergeChange(purpose any, value any) error {
+	return nil
+}
+
+func (s graphState) Unwrap() g.State {
+	return s
+}
+
+func (s graphState) ReadAttribute(name string) any {
+	return nil
+}
+
+func newAdmin(t *testing.T) (string, testkit.TestProbe, f.ActorRef) {
+	system := b.NewActorSystem("cli-test")
+	probe := testkit.NewTestProbe(t)
+	address, err := url.Parse(echoURI)
+	assert.NilError(t, err)
+	echo, err := b.NewActor(*address, func(msg f.Message, self f.Actor[struct{}]) (struct{}, error) {
+		return self.State(), probe.Deliver(msg.Payload(), self)
+	}, struct{}{}, b.WithSystem(system))
+	assert.NilError(t, err)
+
+	graph, err := b.NewGraph(graphState{}, g.NoConfiguration{})
+	assert.NilError(t, err)
+	root, err := b.NewRootNode(graph)
+	assert.NilError(t, err)
+	end, err := b.NewEndNode(graph)
+	assert.NilError(t, err)
+	assert.NilError(t, root.OneWayRoute("end", end))
+
+	handler, err := b.NewAdminHandler(a.Config{System: system, Graphs: map[string]g.Graph{"flow": graph}})
+	assert.NilError(t, err)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL, probe, echo
+}
+
+func run(ctx context.Context, address string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := cli.Run(ctx, append([]string{"-addr", address}, args...), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI(t *testing.T) {
+	t.Log("CLI test suite")
+
+	address, probe, echo := newAdmin(t)
+	ctx := context.Background()
+
+	t.Run("Inspect the actors", func(t *testing.T) {
+		t.Log("Should list, print and describe the actors")
+
+		code, stdout, _ := run(ctx, address, "ls")
+		assert.Equal(t, code, 0)
+		assert.Equal(t, stdout, echoURI+"\trunning\n")
+
+		code, stdout, _ = run(ctx, address, "tree")
+		assert.Equal(t, code, 0)
+		assert.Assert(t, strings.HasPrefix(stdout, echoURI+" [running]"))
+
+		code, stdout, _ = run(ctx, address, "stat", echoURI)
+		assert.Equal(t, code, 0)
+		assert.Assert(t, strings.Contains(stdout, `"mailbox_capacity": 100`))
+
+		code, stdout, _ = run(ctx, address, "graphs")
+		assert.Equal(t, code, 0)
+		assert.Assert(t, strings.Contains(stdout, `"name": "flow"`))
+	})
+
+	t.Run("Drive the actors", func(t *testing.T) {
+		t.Log("Should send messages and run graphs")
+
+		code, stdout, _ := run(ctx, address, "send", echoURI, `"hello"`)
+		assert.Equal(t, code, 0)
+		assert.Equal(t, stdout, "accepted\n")
+		probe.ExpectMsg("hello")
+
+		code, _, _ = run(ctx, address, "graphs", "run", "flow", `{"go":true}`)
+		assert.Equal(t, code, 0)
+	})
+
+	t.Run("Report the failures", func(t *testing.T) {
+		t.Log("Should exit with 1 on admin errors and with 2 on invalid usage")
+
+		code, _, stderr := run(ctx, address, "stat", "actor://cli-test/missing")
+		assert.Equal(t, code, 1)
+		assert.Assert(t, strings.Contains(stderr, "404"))
+
+		code, _, stderr = run(ctx, address, "send", echoURI)
+		assert.Equal(t, code, 2)
+		assert.Assert(t, strings.Contains(stderr, "Usage"))
+
+		code, _, _ = run(ctx, address, "unknown")
+		assert.Equal(t, code, 2)
+	})
+
+	t.Run("Tail the events and stop", func(t *testing.T) {
+		t.Log("Should print the lifecycle events until interrupted")
+
+		tailCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		output := &syncBuffer{}
+		done := make(chan int)
+		go func() {
+			done <- cli.Run(tailCtx, []string{"-addr", address, "tail", "events"}, output, &bytes.Buffer{})
+		}()
+
+		eventually := func(event string, action ...string) {
+			deadline := time.Now().Add(testkit.DefaultTimeout)
+			for !strings.Contains(output.String(), event) {
+				if time.Now().After(deadline) {
+					t.Fatalf("%s event not printed", event)
+				}
+				if len(action) > 0 {
+					run(ctx, address, action...)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+		eventually("restarted", "restart", echoURI)
+
+		code, _, _ := run(ctx, address, "stop", echoURI)
+		assert.Equal(t, code, 0)
+		eventually("stopped")
+		assert.Assert(t, strings.Contains(output.String(), echoURI))
+		assert.Equal(t, echo.Status(), f.ActorStatusIdle)
+
+		cancel()
+		assert.Equal(t, <-done, 0)
+	})
+}
@@ -3,12 +3,15 @@ package framework
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
 	f "github.com/morphy76/lang-actor/pkg/framework"
+	t "github.com/morphy76/lang-actor/pkg/tracing"
 )
 
 // DefaultMailboxConfig is the default mailbox configuration.
@@ -22,62 +25,24 @@ func NewActor[T any](
 	address url.URL,
 	processingFn f.ProcessingFn[T],
 	initialState T,
-	mailboxConfig ...f.MailboxConfig,
+	options ...f.ActorOption,
 ) (f.Actor[T], error) {
 	// Validate the schema
 	if address.Scheme != "actor" {
 		return nil, f.ErrorInvalidActorAddress
 	}
 
-	useCtx, useCancelFn := context.WithCancel(context.Background())
-
-	config := defaultMailboxConfig
-	if len(mailboxConfig) > 0 {
-		config = mailboxConfig[0]
-	}
-
-	var mailbox chan f.Message
-	switch config.Policy {
-	case f.BackpressurePolicyUnbounded:
-		// Use a reasonable initial size for "unbounded" channels to avoid massive memory allocation
-		mailbox = make(chan f.Message, 10000)
-	default:
-		capacity := config.Capacity
-		if capacity <= 0 {
-			capacity = defaultMailboxConfig.Capacity
-		}
-		mailbox = make(chan f.Message, capacity)
-	}
-
-	rv := &actor[T]{
-		lock: &sync.Mutex{},
-
-		status:        f.ActorStatusRunning,
-		stopCompleted: make(chan bool, 1),
-
-		ctx:       useCtx,
-		ctxCancel: useCancelFn,
-
-		address:       address,
-		mailbox:       mailbox,
-		mailboxConfig: config,
-		processingFn:  processingFn,
-
-		children: make(map[url.URL]f.ActorRef),
-
-		state: initialState,
-	}
-	go rv.consume()
-
-	return rv, nil
+	return newActor(address, processingFn, initialState, nil, NewActorConfig(options...))
 }
 
 // NewActorWithParent creates a new actor with the given address and parent actor.
+//
+// The child inherits the settings of the parent, except the mailbox configuration, unless overridden by the given options.
 func NewActorWithParent[T any](
 	processingFn f.ProcessingFn[T],
 	initialState T,
 	parent f.ActorRef,
-	mailboxConfig ...f.MailboxConfig,
+	options ...f.ActorOption,
 ) (f.Actor[T], error) {
 	address, err := url.Parse(fmt.Sprintf(
 		"actor://%s%s",
@@ -88,26 +53,77 @@ func NewActorWithParent[T any](
 		return nil, fmt.Errorf("failed to parse actor address: %w", err)
 	}
 
-	useCtx, useCancelFn := context.WithCancel(context.Background())
+	base := f.ActorConfig{
+		Mailbox: defaultMailboxConfig,
+	}
+	if configured, ok := parent.(configurable); ok {
+		base = configured.actorConfig()
+		base.Mailbox = defaultMailboxConfig
+	}
 
-	config := defaultMailboxConfig
-	if len(mailboxConfig) > 0 {
-		config = mailboxConfig[0]
+	rv, err := newActor(*address, processingFn, initialState, parent, newActorConfig(base, options...))
+	if err != nil {
+		return nil, err
 	}
 
-	var mailbox chan f.Message
-	switch config.Policy {
-	case f.BackpressurePolicyUnbounded:
-		// Use a reasonable initial size for "unbounded" channels to avoid massive memory allocation
-		mailbox = make(chan f.Message, 10000)
-	default:
-		capacity := config.Capacity
-		if capacity <= 0 {
-			capacity = defaultMailboxConfig.Capacity
+	if err := parent.Append(rv); err != nil {
+		// The child already started, stop it so that it does not outlive the failure
+		if stopCompleted, stopErr := rv.Stop(); stopErr == nil {
+			<-stopCompleted
 		}
-		mailbox = make(chan f.Message, capacity)
+		return nil, fmt.Errorf("failed to append child to parent: %w", err)
 	}
 
+	return rv, nil
+}
+
+// NewActorConfig applies the given options on top of the default actor configuration.
+//
+// When the options set an actor system, the system options are applied first.
+func NewActorConfig(options ...f.ActorOption) f.ActorConfig {
+	return newActorConfig(f.ActorConfig{Mailbox: defaultMailboxConfig}, options...)
+}
+
+func newActorConfig(base f.ActorConfig, options ...f.ActorOption) f.ActorConfig {
+	config := base
+	for _, option := range options {
+		option.Apply(&config)
+	}
+
+	if config.System != nil && config.System != base.System {
+		system := config.System
+		config = base
+		for _, option := range system.Options() {
+			option.Apply(&config)
+		}
+		config.System = system
+		config.Logger = system.Logger()
+		for _, option := range options {
+			option.Apply(&config)
+		}
+	}
+
+	if config.Metrics == nil {
+		config.Metrics = noopMetrics{}
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	if config.Clock == nil {
+		config.Clock = NewSystemClock()
+	}
+	return config
+}
+
+func newActor[T any](
+	address url.URL,
+	processingFn f.ProcessingFn[T],
+	initialState T,
+	parent f.ActorRef,
+	config f.ActorConfig,
+) (*actor[T], error) {
+	useCtx, useCancelFn := context.WithCancel(context.Background())
+
 	rv := &actor[T]{
 		lock: &sync.Mutex{},
 
@@ -117,21 +133,62 @@ func NewActorWithParent[T any](
 		ctx:       useCtx,
 		ctxCancel: useCancelFn,
 
-		address:       *address,
-		mailbox:       mailbox,
-		mailboxConfig: config,
+		address:       address,
+		config:        config,
+		mailbox:       newMailbox(config.Mailbox),
+		mailboxConfig: config.Mailbox,
+		throttle:      newTokenBucket(config.Mailbox.Throttle, config.Clock),
+		pacing:        &atomic.Bool{},
 		processingFn:  processingFn,
+		interceptors:  config.Interceptors,
+		metrics:       config.Metrics,
+		tracer:        config.Tracer,
+		inflight:      &atomic.Pointer[t.SpanContext]{},
+		logger:        config.Logger.With(f.LogAttrActorAddress, address.String()),
+		clock:         config.Clock,
+		dispatcher:    config.Dispatcher,
+		scheduled:     &atomic.Bool{},
+		terminated:    &atomic.Bool{},
+		startedAt:     config.Clock.Now(),
+		processed:     &atomic.Uint64{},
 
 		parent:   parent,
 		children: make(map[url.URL]f.ActorRef),
+		watchers: make(map[url.URL]f.ActorRef),
 
-		state: initialState,
+		initialState: initialState,
+		state:        initialState,
+	}
+	if config.AddressBook != nil {
+		if err := config.AddressBook.Register(rv); err != nil {
+			useCancelFn()
+			return nil, fmt.Errorf("failed to register actor: %w", err)
+		}
 	}
-	go rv.consume()
 
-	if err := parent.Append(rv); err != nil {
-		return nil, fmt.Errorf("failed to append child to parent: %w", err)
+	if tracker, ok := config.System.(lifecycleTracker); ok && parent == nil {
+		tracker.adopt(rv)
+	}
+	rv.publish(f.LifecycleStarted, nil)
+
+	rv.metrics.ActorStarted(rv.address)
+	if rv.dispatcher == nil {
+		go rv.consume()
 	}
 
 	return rv, nil
 }
+
+func newMailbox(config f.MailboxConfig) chan f.Message {
+	switch config.Policy {
+	case f.BackpressurePolicyUnbounded:
+		// Use a reasonable initial size for "unbounded" channels to avoid massive memory allocation
+		return make(chan f.Message, 10000)
+	default:
+		capacity := config.Capacity
+		if capacity <= 0 {
+			capacity = defaultMailboxConfig.Capacity
+		}
+		return make(chan f.Message, capacity)
+	}
+}
@@ -3,20 +3,33 @@ package framework
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	c "github.com/morphy76/lang-actor/pkg/common"
 	f "github.com/morphy76/lang-actor/pkg/framework"
+	t "github.com/morphy76/lang-actor/pkg/tracing"
 )
 
 var staticActorAssertion f.Actor[any] = (*actor[any])(nil)
 var staticMessageAssertion f.Message = (*actorMessage)(nil)
+var staticDispatchableAssertion f.Dispatchable = (*actor[any])(nil)
+var staticInspectableAssertion f.Inspectable = (*actor[any])(nil)
+var staticRestartableAssertion f.Restartable = (*actor[any])(nil)
+
+// drainTimeout bounds the processing of the pending messages once the actor is stopped.
+const drainTimeout = 5 * time.Second
+
+// restartSignal resets the actor state when processed, after the messages already in the mailbox.
+type restartSignal struct{}
 
 type actorMessage struct {
-	payload any
-	from    c.Addressable
+	payload  any
+	from     c.Addressable
+	metadata c.Metadata
 }
 
 func (m actorMessage) Payload() any {
@@ -24,9 +37,31 @@ func (m actorMessage) Payload() any {
 }
 
 func (m actorMessage) Sender() url.URL {
+	if m.from == nil {
+		return url.URL{}
+	}
 	return m.from.Address()
 }
 
+func (m actorMessage) Metadata() c.Metadata {
+	return m.metadata
+}
+
+// configurable is implemented by actors sharing their settings with their children.
+type configurable interface {
+	actorConfig() f.ActorConfig
+}
+
+// traceCarrier is implemented by senders able to propagate the context of the message they are processing.
+type traceCarrier interface {
+	inflightContext() (t.SpanContext, bool)
+}
+
+// dispatchedActor is implemented by the actors whose turns are given by a dispatcher.
+type dispatchedActor interface {
+	dispatched() bool
+}
+
 type actor[T any] struct {
 	lock *sync.Mutex
 
@@ -37,18 +72,35 @@ type actor[T any] struct {
 	ctxCancel context.CancelFunc
 
 	address       url.URL
+	config        f.ActorConfig
 	mailbox       chan f.Message
 	mailboxConfig f.MailboxConfig
+	throttle      *tokenBucket
+	pacing        *atomic.Bool
 	processingFn  f.ProcessingFn[T]
+	interceptors  []f.Interceptor
+	metrics       f.Metrics
+	tracer        t.Tracer
+	inflight      *atomic.Pointer[t.SpanContext]
+	logger        *slog.Logger
+	clock         f.Clock
+	dispatcher    f.Dispatcher
+	scheduled     *atomic.Bool
+	terminated    *atomic.Bool
+	startedAt     time.Time
+	processed     *atomic.Uint64
+	lastError     error
 
 	parent   f.ActorRef
 	children map[url.URL]f.ActorRef
+	watchers map[url.URL]f.ActorRef
 
-	state T
+	initialState T
+	state        T
 }
 
 // Address returns the actor's address.
-func (a actor[T]) Address() url.URL {
+func (a *actor[T]) Address() url.URL {
 	return a.address
 }
 
@@ -68,24 +120,49 @@ func (a *actor[T]) Stop() (chan bool, error) {
 	}
 
 	a.lock.Lock()
-	defer a.lock.Unlock()
-
-	if a.status == f.ActorStatusRunning {
-		return a.teardown()
+	if a.status != f.ActorStatusRunning {
+		a.lock.Unlock()
+		return nil, fmt.Errorf("cannot stop actor: %w", f.ErrorActorNotRunning)
 	}
+	a.teardown()
+	a.lock.Unlock()
 
-	return nil, fmt.Errorf("cannot stop actor: %w", f.ErrorActorNotRunning)
+	// Schedule the last turn without holding the lock, it may run on this goroutine
+	a.schedule()
+	return a.stopCompleted, nil
 }
 
 // Deliver delivers a message to the actor.
 func (a *actor[T]) Deliver(msg any, from c.Addressable) error {
-	if a.status != f.ActorStatusRunning {
+	if a.Status() != f.ActorStatusRunning {
 		return fmt.Errorf("failed to deliver message: %w", f.ErrorActorNotRunning)
 	}
 
+	payload, metadata := c.Unwrap(msg)
+	if _, found := metadata[t.TraceparentKey]; !found {
+		if carrier, ok := from.(traceCarrier); ok {
+			if sc, ok := carrier.inflightContext(); ok {
+				metadata = metadata.Clone()
+				t.Inject(sc, metadata)
+			}
+		}
+	}
+
 	useMessage := actorMessage{
-		payload: msg,
-		from:    from,
+		payload:  payload,
+		from:     from,
+		metadata: metadata,
+	}
+
+	if a.throttle != nil && a.throttle.overflow != f.ThrottleOverflowQueue {
+		if _, restart := payload.(restartSignal); !restart && !a.throttle.take() {
+			if a.throttle.overflow == f.ThrottleOverflowDrop {
+				a.metrics.MessageDropped(a.address, a.mailboxConfig.Policy)
+				return nil
+			}
+			a.metrics.MessageRejected(a.address, a.mailboxConfig.Policy)
+			return fmt.Errorf("failed to deliver message: %w", f.ErrorThrottled)
+		}
 	}
 
 	switch a.mailboxConfig.Policy {
@@ -95,6 +172,7 @@ func (a *actor[T]) Deliver(msg any, from c.Addressable) error {
 		select {
 		case a.mailbox <- useMessage:
 		default:
+			a.metrics.MessageRejected(a.address, a.mailboxConfig.Policy)
 			return fmt.Errorf("mailbox full: message rejected")
 		}
 	case f.BackpressurePolicyDropNewest:
@@ -103,17 +181,21 @@ func (a *actor[T]) Deliver(msg any, from c.Addressable) error {
 		default:
 			// Mailbox is full, silently drop the message
 			// This is intentional as per the policy
+			a.metrics.MessageDropped(a.address, a.mailboxConfig.Policy)
+			return nil
 		}
 	case f.BackpressurePolicyDropOldest:
 		if len(a.mailbox) == cap(a.mailbox) && cap(a.mailbox) > 0 {
 			select {
 			case <-a.mailbox:
+				a.metrics.MessageDropped(a.address, a.mailboxConfig.Policy)
 			default:
 			}
 		}
 		select {
 		case a.mailbox <- useMessage:
 		default:
+			a.metrics.MessageRejected(a.address, a.mailboxConfig.Policy)
 			return fmt.Errorf("failed to deliver message: mailbox state changed unexpectedly")
 		}
 
@@ -124,6 +206,9 @@ func (a *actor[T]) Deliver(msg any, from c.Addressable) error {
 		a.mailbox <- useMessage
 	}
 
+	a.metrics.MessageEnqueued(a.address, a.mailboxConfig.Policy, len(a.mailbox))
+	a.schedule()
+
 	return nil
 }
 
@@ -134,13 +219,23 @@ func (a *actor[T]) State() T {
 	return a.state
 }
 
-// Status returns, by value, the actor's status.
-func (a actor[T]) Status() f.ActorStatus {
+// Status returns the actor's status.
+func (a *actor[T]) Status() f.ActorStatus {
+	a.lock.Lock()
+	defer a.lock.Unlock()
 	return a.status
 }
 
-// Send sends a message to the actor.
-func (a actor[T]) Send(msg any, destination c.Transport) error {
+// Send sends a message to the destination, propagating the trace context of the message being processed.
+func (a *actor[T]) Send(msg any, destination c.Transport) error {
+	if sc, ok := a.inflightContext(); ok {
+		payload, metadata := c.Unwrap(msg)
+		if _, found := metadata[t.TraceparentKey]; !found {
+			metadata = metadata.Clone()
+			t.Inject(sc, metadata)
+			msg = c.Envelope{Payload: payload, Metadata: metadata}
+		}
+	}
 	return destination.Deliver(msg, a)
 }
 
@@ -164,16 +259,45 @@ func (a *actor[T]) Append(child f.ActorRef) error {
 // Crop removes a child actor from the actor.
 func (a *actor[T]) Crop(url url.URL) (f.ActorRef, error) {
 	a.lock.Lock()
-	defer a.lock.Unlock()
-
-	if child, ok := a.children[url]; !ok {
+	child, ok := a.children[url]
+	if !ok {
+		a.lock.Unlock()
 		return nil, f.ErrorInvalidChildURL
-	} else {
-		stopCompleted, _ := child.Stop()
-		<-stopCompleted
-		delete(a.children, url)
+	}
+	delete(a.children, url)
+	a.lock.Unlock()
+
+	// The lock is released before waiting, the child notifies its watchers, possibly the parent itself
+	stopCompleted, err := child.Stop()
+	if err != nil {
 		return child, nil
 	}
+	// A dispatched child stops on its next turn, which may be given by the goroutine cropping it
+	if dispatched, ok := child.(dispatchedActor); !ok || !dispatched.dispatched() {
+		<-stopCompleted
+	}
+	return child, nil
+}
+
+// Watch registers a watcher to be notified when the actor stops.
+func (a *actor[T]) Watch(watcher f.ActorRef) error {
+	a.lock.Lock()
+	if a.status == f.ActorStatusRunning {
+		a.watchers[watcher.Address()] = watcher
+		a.lock.Unlock()
+		return nil
+	}
+	a.lock.Unlock()
+
+	return watcher.Deliver(f.Terminated{Address: a.address}, a)
+}
+
+// Unwatch removes a watcher.
+func (a *actor[T]) Unwatch(watcher f.ActorRef) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.watchers, watcher.Address())
 }
 
 // GetParent returns the parent actor of the current actor.
@@ -184,8 +308,16 @@ func (a *actor[T]) GetParent() (f.ActorRef, bool) {
 	return a.parent, true
 }
 
+// Logger returns the logger scoped with the actor address.
+func (a *actor[T]) Logger() *slog.Logger {
+	return a.logger
+}
+
 // Children returns the children of the actor.
 func (a *actor[T]) Children() []f.ActorRef {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
 	children := make([]f.ActorRef, 0, len(a.children))
 	for _, child := range a.children {
 		children = append(children, child)
@@ -218,47 +350,116 @@ func (a *actor[T]) verifyChildURL(url url.URL) error {
 	return nil
 }
 
-func (a *actor[T]) teardown() (chan bool, error) {
+// Restart resets the actor state to its initial state, once the messages already in the mailbox are processed.
+func (a *actor[T]) Restart() error {
+	return a.Deliver(restartSignal{}, a)
+}
+
+// Stats returns a snapshot of the runtime figures of the actor.
+func (a *actor[T]) Stats() f.ActorStats {
+	a.lock.Lock()
+	lastError := a.lastError
+	a.lock.Unlock()
+
+	capacity := cap(a.mailbox)
+	if a.mailboxConfig.Policy == f.BackpressurePolicyUnbounded {
+		capacity = 0
+	}
+	return f.ActorStats{
+		MailboxDepth:    len(a.mailbox),
+		MailboxCapacity: capacity,
+		Policy:          a.mailboxConfig.Policy,
+		Processed:       a.processed.Load(),
+		LastError:       lastError,
+		Uptime:          a.clock.Since(a.startedAt),
+	}
+}
+
+// Run processes up to throughput pending messages, on behalf of the dispatcher.
+func (a *actor[T]) Run(throughput int) bool {
+	if a.terminated.Load() {
+		return false
+	}
+
+	if a.ctx.Err() != nil {
+		deadline := a.clock.Now().Add(drainTimeout)
+		for a.clock.Now().Before(deadline) {
+			msg, ok := a.poll()
+			if !ok {
+				break
+			}
+			a.process(msg)
+		}
+		a.terminate()
+		return false
+	}
+
+	for range max(throughput, 1) {
+		if wait := a.throttleDelay(); wait > 0 {
+			// resume when the rate allows, without holding the worker
+			if a.pacing.CompareAndSwap(false, true) {
+				a.clock.AfterFunc(wait, func() {
+					a.pacing.Store(false)
+					a.schedule()
+				})
+			}
+			a.scheduled.Store(false)
+			return false
+		}
+		msg, ok := a.poll()
+		if !ok {
+			break
+		}
+		a.pace()
+		a.process(msg)
+	}
+
+	a.scheduled.Store(false)
+	if len(a.mailbox) > 0 || a.ctx.Err() != nil {
+		return a.scheduled.CompareAndSwap(false, true)
+	}
+	return false
+}
+
+func (a *actor[T]) dispatched() bool {
+	return a.dispatcher != nil
+}
+
+func (a *actor[T]) teardown() {
 	// Lock is already held by caller (Stop method)
 	a.ctxCancel()
-	return a.stopCompleted, nil
 }
 
-func (a *actor[T]) consume() {
-	defer func() {
-		// Ensure status is set and channel is closed on any exit
-		a.lock.Lock()
-		a.status = f.ActorStatusIdle
-		a.lock.Unlock()
+func (a *actor[T]) poll() (f.Message, bool) {
+	select {
+	case msg := <-a.mailbox:
+		return msg, true
+	default:
+		return nil, false
+	}
+}
 
-		// Send completion signal (non-blocking)
-		select {
-		case a.stopCompleted <- true:
-		default:
-		}
-	}()
+func (a *actor[T]) schedule() {
+	if a.dispatcher != nil && a.scheduled.CompareAndSwap(false, true) {
+		a.dispatcher.Schedule(a)
+	}
+}
+
+func (a *actor[T]) consume() {
+	defer a.terminate()
 
 	for {
 		select {
 		case msg := <-a.mailbox:
-			newState, err := a.processingFn(msg, a)
-			if err != nil {
-				a.handleFailure(err)
-			}
-
-			a.swapState(newState)
+			a.pace()
+			a.process(msg)
 		case <-a.ctx.Done():
-			cleanupTimeout := time.After(5 * time.Second)
+			cleanupTimeout := a.clock.After(drainTimeout)
 		drainLoop:
 			for {
 				select {
 				case msg := <-a.mailbox:
-					newState, err := a.processingFn(msg, a)
-					if err != nil {
-						a.handleFailure(err)
-					}
-
-					a.swapState(newState)
+					a.process(msg)
 				case <-cleanupTimeout:
 					return
 				default:
@@ -270,18 +471,146 @@ func (a *actor[T]) consume() {
 	}
 }
 
+// throttleDelay returns the time until the next queued message can be processed, zero when unthrottled.
+func (a *actor[T]) throttleDelay() time.Duration {
+	if a.throttle == nil || a.throttle.overflow != f.ThrottleOverflowQueue || len(a.mailbox) == 0 {
+		return 0
+	}
+	return a.throttle.delay()
+}
+
+// pace waits for the rate to allow the processing of a queued message, unless the actor is stopping.
+func (a *actor[T]) pace() {
+	if a.throttle == nil || a.throttle.overflow != f.ThrottleOverflowQueue {
+		return
+	}
+	for !a.throttle.take() {
+		select {
+		case <-a.clock.After(a.throttle.delay()):
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+func (a *actor[T]) terminate() {
+	if !a.terminated.CompareAndSwap(false, true) {
+		return
+	}
+
+	a.lock.Lock()
+	a.status = f.ActorStatusIdle
+	watchers := make([]f.ActorRef, 0, len(a.watchers))
+	for _, watcher := range a.watchers {
+		watchers = append(watchers, watcher)
+	}
+	clear(a.watchers)
+	a.lock.Unlock()
+	a.metrics.ActorStopped(a.address)
+
+	if tracker, ok := a.config.System.(lifecycleTracker); ok && a.parent == nil {
+		tracker.release(a)
+	}
+	a.publish(f.LifecycleStopped, nil)
+
+	if book := a.config.AddressBook; book != nil {
+		if registered, found := book.Resolve(a.address); found && registered == c.Addressable(a) {
+			if err := book.Unregister(a.address); err != nil {
+				a.logger.Debug("actor not unregistered", f.LogAttrError, err)
+			}
+		}
+	}
+
+	for _, watcher := range watchers {
+		if err := watcher.Deliver(f.Terminated{Address: a.address}, a); err != nil {
+			watcherAddress := watcher.Address()
+			a.logger.Debug("termination not delivered to watcher", "watcher", watcherAddress.String(), f.LogAttrError, err)
+		}
+	}
+
+	// Send completion signal (non-blocking)
+	select {
+	case a.stopCompleted <- true:
+	default:
+	}
+}
+
+func (a *actor[T]) process(msg f.Message) {
+	if _, restart := msg.Payload().(restartSignal); restart {
+		a.lock.Lock()
+		a.state = a.initialState
+		a.lastError = nil
+		a.lock.Unlock()
+		a.publish(f.LifecycleRestarted, nil)
+		return
+	}
+
+	current, _ := t.Extract(msg.Metadata())
+	var span t.Span
+	if a.tracer != nil {
+		span = a.tracer.Start(
+			current,
+			"actor.process",
+			t.Attribute{Key: t.AttributeActorAddress, Value: a.address.String()},
+			t.Attribute{Key: t.AttributeMessageType, Value: fmt.Sprintf("%T", msg.Payload())},
+		)
+		current = span.Context()
+	}
+	a.inflight.Store(&current)
+
+	start := a.clock.Now()
+	newState, err := a.invoke(msg)
+	a.metrics.MessageProcessed(a.address, a.clock.Since(start), len(a.mailbox), err)
+	a.processed.Add(1)
+
+	a.inflight.Store(nil)
+	if span != nil {
+		span.End(err)
+	}
+	if err != nil {
+		a.handleFailure(msg, err)
+	}
+
+	a.swapState(newState)
+}
+
+func (a *actor[T]) publish(kind f.LifecycleEventKind, err error) {
+	if tracker, ok := a.config.System.(lifecycleTracker); ok {
+		tracker.publish(f.LifecycleEvent{Kind: kind, Address: a.address, Time: a.clock.Now(), Err: err})
+	}
+}
+
+func (a *actor[T]) actorConfig() f.ActorConfig {
+	return a.config
+}
+
+func (a *actor[T]) inflightContext() (t.SpanContext, bool) {
+	sc := a.inflight.Load()
+	if sc == nil || !sc.IsValid() {
+		return t.SpanContext{}, false
+	}
+	return *sc, true
+}
+
 func (a *actor[T]) swapState(newState T) {
 	a.lock.Lock()
 	defer a.lock.Unlock()
 	a.state = newState
 }
 
-func (a *actor[T]) handleFailure(err error) {
+func (a *actor[T]) handleFailure(msg f.Message, err error) {
+	a.lock.Lock()
+	a.lastError = err
+	a.lock.Unlock()
+	a.publish(f.LifecycleFailed, err)
+
 	// TODO: Implement proper error handling strategy:
 	// - Error escalation to parent actors
 	// - Configurable error recovery policies
-	// - Structured logging
-	// - Metrics/monitoring integration
 	// For now, just log the error
-	fmt.Printf("Actor [%v] error: %s\n", a.address, err)
+	a.logger.Error(
+		"message processing failed",
+		f.LogAttrMessageType, fmt.Sprintf("%T", msg.Payload()),
+		f.LogAttrError, err,
+	)
 }
@@ -1,13 +1,29 @@
 package framework_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 
 	"github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/internal/metrics"
+	"github.com/morphy76/lang-actor/internal/tracing"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	c "github.com/morphy76/lang-actor/pkg/common"
 	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+	tr "github.com/morphy76/lang-actor/pkg/tracing"
 )
 
 const actorURI = "actor://example"
@@ -81,6 +97,33 @@ func TestActorLifecycle(t *testing.T) {
 
 		assert.Equal(t, actor.Status(), f.ActorStatusIdle)
 	})
+
+	t.Run("Stop a parent watching its child", func(t *testing.T) {
+		t.Log("Should stop the parent when it is notified of the termination of its child")
+
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+
+		parent, err := framework.NewActor(*address, nullProcessingFn, initialState)
+		assert.NilError(t, err)
+		child, err := framework.NewActorWithParent(nullProcessingFn, initialState, parent)
+		assert.NilError(t, err)
+		assert.NilError(t, child.Watch(parent))
+
+		stopped := make(chan bool)
+		go func() {
+			stopCompleted, _ := parent.Stop()
+			stopped <- <-stopCompleted
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("parent did not stop")
+		}
+		assert.Equal(t, child.Status(), f.ActorStatusIdle)
+		assert.Equal(t, parent.Status(), f.ActorStatusIdle)
+	})
 }
 
 type mockActorState struct {
@@ -398,3 +441,483 @@ func TestBackpressurePolicies(t *testing.T) {
 type mockMessageWithID struct {
 	id string
 }
+
+func TestActorMetrics(t *testing.T) {
+	t.Log("Actor metrics test suite")
+
+	t.Run("Instrument mailbox and processing", func(t *testing.T) {
+		t.Log("Should record enqueued, rejected and processed messages and the actor lifecycle")
+
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+
+		registry := metrics.NewRegistry()
+		blockCh := make(chan struct{})
+		processingFn := func(msg f.Message, actor f.Actor[noState]) (noState, error) {
+			<-blockCh
+			return noState{}, nil
+		}
+
+		config := f.MailboxConfig{
+			Capacity: 1,
+			Policy:   f.BackpressurePolicyFail,
+		}
+		actor, err := framework.NewActor(*address, processingFn, noState{}, config, b.WithMetrics(registry))
+		assert.NilError(t, err)
+
+		assert.NilError(t, actor.Deliver("", nil))
+		delivered := uint64(1)
+		for actor.Deliver("", nil) == nil {
+			delivered++
+		}
+		close(blockCh)
+		for actor.(f.Inspectable).Stats().Processed < delivered {
+		}
+
+		var sb strings.Builder
+		assert.NilError(t, registry.WriteText(&sb))
+		text := sb.String()
+
+		assert.Assert(t, strings.Contains(text, "lang_actor_actors_live 1\n"))
+		assert.Assert(t, strings.Contains(text, `lang_actor_messages_rejected_total{actor="actor://example",policy="fail"} 1`))
+		assert.Assert(t, strings.Contains(text, fmt.Sprintf(`lang_actor_processing_duration_seconds_count{actor="actor://example"} %d`, delivered)))
+
+		stopCompleted, err := actor.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+
+		sb.Reset()
+		assert.NilError(t, registry.WriteText(&sb))
+		text = sb.String()
+
+		assert.Assert(t, strings.Contains(text, "lang_actor_actors_live 0\n"))
+		assert.Assert(t, !strings.Contains(text, `actor="actor://example"`))
+	})
+}
+
+type recordingExporter struct {
+	lock  sync.Mutex
+	spans []tr.SpanData
+}
+
+func (e *recordingExporter) Export(spans []tr.SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestActorTracing(t *testing.T) {
+	t.Log("Actor tracing test suite")
+
+	t.Run("Propagate the trace context across actors", func(t *testing.T) {
+		t.Log("Should create a span per processing and chain the spans of the actors in the same trace")
+
+		exporter := &recordingExporter{}
+		tracer := tracing.NewTracer(exporter)
+
+		downstreamURL, err := url.Parse("actor://downstream")
+		assert.NilError(t, err)
+		received := make(chan f.Message, 1)
+		downstream, err := framework.NewActor(*downstreamURL, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			received <- msg
+			return noState{}, nil
+		}, noState{}, b.WithTracer(tracer))
+		assert.NilError(t, err)
+
+		upstreamURL, err := url.Parse("actor://upstream")
+		assert.NilError(t, err)
+		upstream, err := framework.NewActor(*upstreamURL, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return noState{}, self.Send(msg.Payload(), downstream)
+		}, noState{}, b.WithTracer(tracer))
+		assert.NilError(t, err)
+
+		parent, err := tr.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		assert.NilError(t, err)
+		metadata := c.Metadata{}
+		tr.Inject(parent, metadata)
+		assert.NilError(t, upstream.Deliver(c.Envelope{Payload: "hello", Metadata: metadata}, nil))
+
+		msg := <-received
+		assert.Equal(t, msg.Payload(), "hello")
+		propagated, found := tr.Extract(msg.Metadata())
+		assert.Assert(t, found)
+		assert.Equal(t, propagated.TraceID, parent.TraceID)
+
+		for _, actor := range []f.ActorRef{upstream, downstream} {
+			stopCompleted, err := actor.Stop()
+			assert.NilError(t, err)
+			<-stopCompleted
+		}
+		assert.NilError(t, tracer.Shutdown())
+
+		spans := make(map[string]tr.SpanData)
+		for _, span := range exporter.spans {
+			for _, attr := range span.Attributes {
+				if attr.Key == tr.AttributeActorAddress {
+					spans[attr.Value.(string)] = span
+				}
+			}
+		}
+		assert.Equal(t, len(spans), 2)
+		assert.Equal(t, spans["actor://upstream"].Parent, parent.SpanID)
+		assert.Equal(t, spans["actor://downstream"].Parent, spans["actor://upstream"].Context.SpanID)
+		assert.Equal(t, spans["actor://downstream"].Context.TraceID, parent.TraceID)
+	})
+
+	t.Run("Deliver a nil envelope", func(t *testing.T) {
+		t.Log("Should deliver a nil envelope as a bare payload without metadata")
+
+		probe := testkit.NewTestProbe(t)
+		address, err := url.Parse("actor://nil-envelope")
+		assert.NilError(t, err)
+		received := make(chan f.Message, 1)
+		actor, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			received <- msg
+			return noState{}, nil
+		}, noState{})
+		assert.NilError(t, err)
+		defer stop(t, actor)
+
+		var envelope *c.Envelope
+		assert.NilError(t, actor.Deliver(envelope, probe))
+		msg := <-received
+		assert.Equal(t, msg.Payload(), any(envelope))
+		assert.Equal(t, len(msg.Metadata()), 0)
+	})
+}
+
+func TestActorLogging(t *testing.T) {
+	t.Log("Actor logging test suite")
+
+	t.Run("Log processing failures with structured attributes", func(t *testing.T) {
+		t.Log("Should log processing failures to the actor logger with the actor address and message type")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+
+		actor, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			self.Logger().Info("processing")
+			return noState{}, errors.New("boom")
+		}, noState{}, b.WithLogger(logger))
+		assert.NilError(t, err)
+
+		assert.NilError(t, actor.Deliver("hello", nil))
+		stopCompleted, err := actor.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+
+		records := decodeRecords(t, &buffer)
+		assert.Equal(t, len(records), 2)
+		assert.Equal(t, records[0]["msg"], "processing")
+		assert.Equal(t, records[0][f.LogAttrActorAddress], actorURI)
+		assert.Equal(t, records[1]["level"], "ERROR")
+		assert.Equal(t, records[1][f.LogAttrActorAddress], actorURI)
+		assert.Equal(t, records[1][f.LogAttrMessageType], "string")
+		assert.Equal(t, records[1][f.LogAttrError], "boom")
+	})
+
+	t.Run("Inherit the system logger", func(t *testing.T) {
+		t.Log("Should scope the logger of the actors with the system name, children included")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+		system := framework.NewActorSystem("test-system", b.WithLogger(logger))
+
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+
+		parent, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return noState{}, nil
+		}, noState{}, b.WithSystem(system))
+		assert.NilError(t, err)
+
+		child, err := framework.NewActorWithParent(func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return noState{}, nil
+		}, noState{}, parent)
+		assert.NilError(t, err)
+
+		parent.Logger().Info("from parent")
+		child.Logger().Info("from child")
+
+		stopCompleted, err := parent.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+
+		records := decodeRecords(t, &buffer)
+		assert.Equal(t, len(records), 2)
+		for _, record := range records {
+			assert.Equal(t, record[f.LogAttrSystem], "test-system")
+		}
+		childAddress := child.Address()
+		assert.Equal(t, records[1][f.LogAttrActorAddress], childAddress.String())
+	})
+}
+
+func TestActorDispatchers(t *testing.T) {
+	t.Log("Actor dispatchers test suite")
+
+	t.Run("Share a bounded pool", func(t *testing.T) {
+		t.Log("Should run many actors on a few goroutines")
+
+		dispatcher := framework.NewPoolDispatcher(4, 10)
+		defer dispatcher.Shutdown()
+
+		processed := &atomic.Int64{}
+		done := &sync.WaitGroup{}
+		before := runtime.NumGoroutine()
+
+		actors := make([]f.Actor[noState], 0, 2000)
+		for i := range 2000 {
+			address, err := url.Parse(fmt.Sprintf("%s/%d", actorURI, i))
+			assert.NilError(t, err)
+			actor, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+				processed.Add(1)
+				done.Done()
+				return noState{}, nil
+			}, noState{}, b.WithDispatcher(dispatcher))
+			assert.NilError(t, err)
+			actors = append(actors, actor)
+		}
+		assert.Assert(t, runtime.NumGoroutine()-before < 100)
+
+		done.Add(len(actors))
+		for _, actor := range actors {
+			assert.NilError(t, actor.Deliver("hello", actor))
+		}
+		done.Wait()
+		assert.Equal(t, processed.Load(), int64(len(actors)))
+
+		for _, actor := range actors {
+			stopCompleted, err := actor.Stop()
+			assert.NilError(t, err)
+			<-stopCompleted
+			assert.Equal(t, actor.Status(), f.ActorStatusIdle)
+		}
+	})
+
+	t.Run("Yield after the throughput", func(t *testing.T) {
+		t.Log("Should interleave the actors of a single worker according to the throughput")
+
+		dispatcher := framework.NewPoolDispatcher(1, 2)
+		defer dispatcher.Shutdown()
+
+		gate := make(chan struct{})
+		gateAddress, err := url.Parse(actorURI + "/gate")
+		assert.NilError(t, err)
+		gateActor, err := framework.NewActor(*gateAddress, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			<-gate
+			return noState{}, nil
+		}, noState{}, b.WithDispatcher(dispatcher))
+		assert.NilError(t, err)
+		assert.NilError(t, gateActor.Deliver("wait", gateActor))
+
+		lock := &sync.Mutex{}
+		order := make([]string, 0)
+		done := &sync.WaitGroup{}
+		done.Add(8)
+		newRecorder := func(name string) f.Actor[noState] {
+			address, err := url.Parse(actorURI + "/" + name)
+			assert.NilError(t, err)
+			actor, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+				lock.Lock()
+				order = append(order, name)
+				lock.Unlock()
+				done.Done()
+				return noState{}, nil
+			}, noState{}, b.WithDispatcher(dispatcher))
+			assert.NilError(t, err)
+			return actor
+		}
+		first, second := newRecorder("a"), newRecorder("b")
+		for range 4 {
+			assert.NilError(t, first.Deliver("hello", first))
+		}
+		for range 4 {
+			assert.NilError(t, second.Deliver("hello", second))
+		}
+
+		close(gate)
+		done.Wait()
+		assert.DeepEqual(t, order, []string{"a", "a", "b", "b", "a", "a", "b", "b"})
+	})
+
+	t.Run("Pin an actor", func(t *testing.T) {
+		t.Log("Should run a pinned actor on its own goroutine, overriding the system dispatcher")
+
+		dispatcher := framework.NewPoolDispatcher(1, 1)
+		defer dispatcher.Shutdown()
+		system := framework.NewActorSystem("pinned-system", b.WithDispatcher(dispatcher))
+
+		gate := make(chan struct{})
+		defer close(gate)
+		gateAddress, err := url.Parse(actorURI + "/gate")
+		assert.NilError(t, err)
+		gateActor, err := framework.NewActor(*gateAddress, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			<-gate
+			return noState{}, nil
+		}, noState{}, b.WithSystem(system))
+		assert.NilError(t, err)
+		assert.NilError(t, gateActor.Deliver("wait", gateActor))
+
+		probe := testkit.NewTestProbe(t)
+		pinnedAddress, err := url.Parse(actorURI + "/pinned")
+		assert.NilError(t, err)
+		pinned, err := framework.NewActor(*pinnedAddress, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return noState{}, self.Send(msg.Payload(), probe)
+		}, noState{}, b.WithSystem(system), b.WithPinnedDispatcher())
+		assert.NilError(t, err)
+
+		assert.NilError(t, pinned.Deliver("hello", probe))
+		probe.ExpectMsg("hello")
+	})
+
+	t.Run("Stop after the shutdown", func(t *testing.T) {
+		t.Log("Should stop an actor whose dispatcher was shut down, running its last turn on the stopping goroutine")
+
+		dispatcher := framework.NewPoolDispatcher(1, 1)
+		probe := testkit.NewTestProbe(t)
+		lateAddress, err := url.Parse(actorURI + "/late")
+		assert.NilError(t, err)
+		actor, err := framework.NewActor(*lateAddress, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return noState{}, self.Send(msg.Payload(), probe)
+		}, noState{}, b.WithDispatcher(dispatcher))
+		assert.NilError(t, err)
+		dispatcher.Shutdown()
+
+		assert.NilError(t, actor.Deliver("hello", probe))
+		probe.ExpectMsg("hello")
+
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			stopCompleted, err := actor.Stop()
+			assert.Check(t, err)
+			<-stopCompleted
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(testkit.DefaultTimeout):
+			t.Fatal("actor not stopped after the shutdown of its dispatcher")
+		}
+		assert.Equal(t, actor.Status(), f.ActorStatusIdle)
+	})
+
+	t.Run("Crop a child on a saturated pool", func(t *testing.T) {
+		t.Log("Should crop a child from the only worker of the pool without waiting for its last turn")
+
+		dispatcher := framework.NewPoolDispatcher(1, 1)
+		defer dispatcher.Shutdown()
+
+		probe := testkit.NewTestProbe(t)
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+		parent, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			cropped, err := self.Crop(msg.Payload().(url.URL))
+			if err != nil {
+				return noState{}, err
+			}
+			return noState{}, self.Send(cropped.Address(), probe)
+		}, noState{}, b.WithDispatcher(dispatcher))
+		assert.NilError(t, err)
+		defer stop(t, parent)
+		child, err := framework.NewActorWithParent(func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return noState{}, nil
+		}, noState{}, parent)
+		assert.NilError(t, err)
+		probe.WatchActor(child)
+
+		assert.NilError(t, parent.Deliver(child.Address(), probe))
+		probe.ExpectMsg(child.Address())
+		probe.ExpectTerminated(child)
+		assert.Equal(t, len(parent.Children()), 0)
+	})
+}
+
+func decodeRecords(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	records := make([]map[string]any, 0)
+	decoder := json.NewDecoder(buffer)
+	for decoder.More() {
+		record := make(map[string]any)
+		assert.NilError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestActorRegistration(t *testing.T) {
+	t.Log("Actor registration test suite")
+
+	var nullProcessingFn f.ProcessingFn[noState] = func(msg f.Message, actor f.Actor[noState]) (noState, error) {
+		return noState{}, nil
+	}
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	t.Run("Register while running", func(t *testing.T) {
+		t.Log("Should register the actor and its children when created and unregister them when stopped")
+
+		addressBook := b.NewAddressBook()
+		actor, err := framework.NewActor(*address, nullProcessingFn, noState{}, b.WithAddressBook(addressBook))
+		assert.NilError(t, err)
+		child, err := framework.NewActorWithParent(nullProcessingFn, noState{}, actor)
+		assert.NilError(t, err)
+
+		registered, found := addressBook.Resolve(*address)
+		assert.Assert(t, found)
+		assert.Equal(t, registered, c.Addressable(actor))
+		_, found = addressBook.Resolve(child.Address())
+		assert.Assert(t, found)
+
+		done, err := actor.Stop()
+		assert.NilError(t, err)
+		<-done
+		_, found = addressBook.Resolve(*address)
+		assert.Assert(t, !found)
+		_, found = addressBook.Resolve(child.Address())
+		assert.Assert(t, !found)
+	})
+
+	t.Run("Fail on a taken address", func(t *testing.T) {
+		t.Log("Should not create an actor whose address is already registered")
+
+		addressBook := b.NewAddressBook()
+		first, err := framework.NewActor(*address, nullProcessingFn, noState{}, b.WithAddressBook(addressBook))
+		assert.NilError(t, err)
+		defer func() {
+			done, _ := first.Stop()
+			<-done
+		}()
+
+		_, err = framework.NewActor(*address, nullProcessingFn, noState{}, b.WithAddressBook(addressBook))
+		assert.ErrorContains(t, err, "actor already registered")
+	})
+	t.Run("Release a child not appended", func(t *testing.T) {
+		t.Log("Should stop and unregister the child when its parent refuses it")
+
+		addressBook := b.NewAddressBook()
+		actor, err := framework.NewActor(*address, nullProcessingFn, noState{})
+		assert.NilError(t, err)
+		defer stop(t, actor)
+
+		_, err = framework.NewActorWithParent(nullProcessingFn, noState{}, refusingParent{ActorRef: actor}, b.WithAddressBook(addressBook))
+		assert.ErrorContains(t, err, "failed to append child to parent")
+
+		children, err := addressBook.Select(actorURI + "/*")
+		assert.NilError(t, err)
+		assert.Equal(t, len(children.Members()), 0)
+	})
+}
+
+// refusingParent fails to append any child.
+type refusingParent struct {
+	f.ActorRef
+}
+
+func (p refusingParent) Append(child f.ActorRef) error {
+	return errors.New("refused")
+}
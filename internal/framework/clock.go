@@ -0,0 +1,61 @@
+package framework
+
+import (
+	"time"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticSystemClockAssertion f.Clock = (*systemClock)(nil)
+var staticSystemTimerAssertion f.Timer = (*systemTimer)(nil)
+
+type systemClock struct{}
+
+// NewSystemClock creates a clock reading the wall time.
+func NewSystemClock() f.Clock {
+	return systemClock{}
+}
+
+// Now returns the current time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Since returns the time elapsed since t.
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTimer creates a new timer sending the current time on its channel after the duration.
+func (systemClock) NewTimer(d time.Duration) f.Timer {
+	return &systemTimer{timer: time.NewTimer(d)}
+}
+
+// AfterFunc waits for the duration to elapse and then calls fn in its own goroutine.
+func (systemClock) AfterFunc(d time.Duration, fn func()) f.Timer {
+	return &systemTimer{timer: time.AfterFunc(d, fn)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+// C returns the channel on which the time is delivered.
+func (t *systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+// Stop prevents the timer from firing.
+func (t *systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Reset changes the timer to expire after the duration.
+func (t *systemTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
@@ -0,0 +1,96 @@
+package framework
+
+import (
+	"sync"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticPoolDispatcherAssertion f.PoolDispatcher = (*poolDispatcher)(nil)
+
+// defaultThroughput is the number of messages an actor processes in a turn when no throughput is given.
+const defaultThroughput = 5
+
+type poolDispatcher struct {
+	lock *sync.Mutex
+	cond *sync.Cond
+
+	queue      []f.Dispatchable
+	throughput int
+	stopped    bool
+	workers    *sync.WaitGroup
+}
+
+// NewPoolDispatcher creates a dispatcher running the actors with pending messages on the given number of workers.
+//
+// Each actor processes at most throughput messages in a turn and then yields the worker to the next actor.
+func NewPoolDispatcher(workers int, throughput int) f.PoolDispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	if throughput <= 0 {
+		throughput = defaultThroughput
+	}
+
+	lock := &sync.Mutex{}
+	rv := &poolDispatcher{
+		lock: lock,
+		cond: sync.NewCond(lock),
+
+		throughput: throughput,
+		workers:    &sync.WaitGroup{},
+	}
+	for range workers {
+		rv.workers.Add(1)
+		go rv.work()
+	}
+
+	return rv
+}
+
+// Schedule requests a turn for the dispatchable.
+func (p *poolDispatcher) Schedule(dispatchable f.Dispatchable) {
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		for dispatchable.Run(p.throughput) {
+		}
+		return
+	}
+	p.queue = append(p.queue, dispatchable)
+	p.cond.Signal()
+	p.lock.Unlock()
+}
+
+// Shutdown stops the workers once the pending turns are completed.
+func (p *poolDispatcher) Shutdown() {
+	p.lock.Lock()
+	p.stopped = true
+	p.cond.Broadcast()
+	p.lock.Unlock()
+
+	p.workers.Wait()
+}
+
+func (p *poolDispatcher) work() {
+	defer p.workers.Done()
+
+	for {
+		p.lock.Lock()
+		for len(p.queue) == 0 && !p.stopped {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.lock.Unlock()
+			return
+		}
+		next := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.lock.Unlock()
+
+		if next.Run(p.throughput) {
+			p.Schedule(next)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticGrainActivatorAssertion f.GrainActivator = (*grainActivator[any])(nil)
+
+// bookKeeper is implemented by the references unregistering themselves from an address book once stopped.
+type bookKeeper interface {
+	keptIn(addressBook r.AddressBook)
+}
+
+type grainActivator[T any] struct {
+	factory     f.ActorFactory[T]
+	passivation f.PassivationConfig[T]
+	options     []f.ActorOption
+}
+
+// NewGrainActivator creates the activator of grains incarnated by the given factory and passivated when idle.
+func NewGrainActivator[T any](
+	factory f.ActorFactory[T],
+	passivation f.PassivationConfig[T],
+	options ...f.ActorOption,
+) f.GrainActivator {
+	return &grainActivator[T]{
+		factory:     factory,
+		passivation: passivation,
+		options:     options,
+	}
+}
+
+// Activate creates the reference of the grain with the given address.
+func (a *grainActivator[T]) Activate(address url.URL, system f.ActorSystem) (f.ActorRef, error) {
+	options := append([]f.ActorOption{withSystem(system)}, a.options...)
+	return NewPassivatingActor(address, a.factory, a.passivation, options...)
+}
+
+// Grain returns the reference of the grain with the given kind and identity, creating it on first use.
+//
+// The reference is kept in the address book of the system, at actor://<system>/<kind>/<id>, until stopped.
+func Grain[T any](system f.ActorSystem, kind string, id string) (f.PassivatingActor[T], error) {
+	address, err := url.Parse(fmt.Sprintf("actor://%s/%s/%s", system.Name(), url.PathEscape(kind), url.PathEscape(id)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse grain address: %w", err)
+	}
+
+	addressBook := system.AddressBook()
+	if found, ok := addressBook.Resolve(*address); ok {
+		return asGrain[T](found)
+	}
+
+	activator, ok := system.Kind(kind)
+	if !ok {
+		return nil, errors.Join(f.ErrorUnknownGrainKind, fmt.Errorf("kind [%s] not registered in system [%s]", kind, system.Name()))
+	}
+	created, err := activator.Activate(*address, system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate grain [%v]: %w", address.String(), err)
+	}
+
+	// A stopped grain leaves the address book, the next caller activates a fresh reference
+	if keeper, ok := created.(bookKeeper); ok {
+		keeper.keptIn(addressBook)
+	}
+	if err := addressBook.Register(created); err != nil {
+		// Another caller registered the grain meanwhile
+		if found, ok := addressBook.Resolve(*address); ok && errors.Is(err, r.ErrorActorAlreadyRegistered) {
+			return asGrain[T](found)
+		}
+		return nil, fmt.Errorf("failed to register grain [%v]: %w", address.String(), err)
+	}
+
+	return asGrain[T](created)
+}
+
+func asGrain[T any](found any) (f.PassivatingActor[T], error) {
+	rv, ok := found.(f.PassivatingActor[T])
+	if !ok {
+		return nil, fmt.Errorf("grain of type [%T] is not a grain of state [%T]", found, *new(T))
+	}
+	return rv, nil
+}
+
+func withSystem(system f.ActorSystem) f.ActorOption {
+	return f.ActorOptionFn(func(config *f.ActorConfig) {
+		config.System = system
+	})
+}
@@ -0,0 +1,123 @@
+package framework_test
+
+import (
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func TestGrain(t *testing.T) {
+	t.Log("Grain test suite")
+
+	newSystem := func(t *testing.T, probe testkit.TestProbe, incarnations *atomic.Int32) f.ActorSystem {
+		system := framework.NewActorSystem("grains")
+		activator := framework.NewGrainActivator(newCounterFactory(probe, incarnations), f.PassivationConfig[int]{})
+		assert.NilError(t, system.RegisterKind("counter", activator))
+		return system
+	}
+
+	t.Run("Activate on first message", func(t *testing.T) {
+		t.Log("Should activate the grain on the first message and keep its identity in the address book")
+
+		probe := testkit.NewTestProbe(t)
+		incarnations := &atomic.Int32{}
+		system := newSystem(t, probe, incarnations)
+
+		alice, err := framework.Grain[int](system, "counter", "alice")
+		assert.NilError(t, err)
+		assert.Equal(t, incarnations.Load(), int32(0))
+
+		aliceAddress := alice.Address()
+		assert.Equal(t, aliceAddress.String(), "actor://grains/counter/alice")
+		resolved, found := system.AddressBook().Resolve(aliceAddress)
+		assert.Assert(t, found)
+		assert.Equal(t, resolved, f.ActorRef(alice))
+
+		assert.NilError(t, alice.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+
+		again, err := framework.Grain[int](system, "counter", "alice")
+		assert.NilError(t, err)
+		assert.NilError(t, again.Deliver("inc", probe))
+		probe.ExpectMsg(2)
+
+		bob, err := framework.Grain[int](system, "counter", "bob")
+		assert.NilError(t, err)
+		assert.NilError(t, bob.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+		assert.Equal(t, incarnations.Load(), int32(2))
+	})
+
+	t.Run("Share the reference among concurrent callers", func(t *testing.T) {
+		t.Log("Should return the same reference to concurrent callers")
+
+		system := newSystem(t, testkit.NewTestProbe(t), &atomic.Int32{})
+
+		refs := make([]f.PassivatingActor[int], 10)
+		wg := &sync.WaitGroup{}
+		for i := range refs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ref, err := framework.Grain[int](system, "counter", "shared")
+				assert.Check(t, err)
+				refs[i] = ref
+			}()
+		}
+		wg.Wait()
+
+		for _, ref := range refs {
+			assert.Equal(t, ref, refs[0])
+		}
+	})
+
+	t.Run("Activate again after a stop", func(t *testing.T) {
+		t.Log("Should leave the address book once stopped and return a fresh reference to the next caller")
+
+		probe := testkit.NewTestProbe(t)
+		incarnations := &atomic.Int32{}
+		system := newSystem(t, probe, incarnations)
+
+		alice, err := framework.Grain[int](system, "counter", "alice")
+		assert.NilError(t, err)
+		assert.NilError(t, alice.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+
+		stopCompleted, err := alice.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		_, found := system.AddressBook().Resolve(alice.Address())
+		assert.Assert(t, !found)
+
+		again, err := framework.Grain[int](system, "counter", "alice")
+		assert.NilError(t, err)
+		assert.Assert(t, again != alice)
+		assert.NilError(t, again.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+		assert.Equal(t, incarnations.Load(), int32(2))
+	})
+
+	t.Run("Reject unknown kinds and state types", func(t *testing.T) {
+		t.Log("Should fail for unregistered kinds, duplicated kinds and mismatching state types")
+
+		system := newSystem(t, testkit.NewTestProbe(t), &atomic.Int32{})
+
+		_, err := framework.Grain[int](system, "unknown", "alice")
+		assert.ErrorIs(t, err, f.ErrorUnknownGrainKind)
+
+		_, err = framework.Grain[string](system, "counter", "alice")
+		assert.ErrorContains(t, err, "is not a grain of state")
+
+		err = system.RegisterKind("counter", framework.NewGrainActivator(func(address url.URL) (f.ProcessingFn[int], int, error) {
+			return nil, 0, nil
+		}, f.PassivationConfig[int]{}))
+		assert.ErrorIs(t, err, f.ErrorGrainKindRegistered)
+	})
+}
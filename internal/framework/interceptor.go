@@ -0,0 +1,50 @@
+package framework
+
+import (
+	"fmt"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// NewRecoverInterceptor creates an interceptor turning the panics of the processing into errors.
+//
+// The state is left unchanged when the processing panics.
+func NewRecoverInterceptor() f.Interceptor {
+	return func(msg f.Message, self f.ActorRef, state any, next f.Next) (rv any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				rv = state
+				err = fmt.Errorf("%w: %v", f.ErrorProcessingPanic, recovered)
+			}
+		}()
+		return next(msg)
+	}
+}
+
+// invoke processes a message through the interceptors, the processing function last.
+func (a *actor[T]) invoke(msg f.Message) (T, error) {
+	if len(a.interceptors) == 0 {
+		return a.processingFn(msg, a)
+	}
+
+	var call func(index int, msg f.Message) (any, error)
+	call = func(index int, msg f.Message) (any, error) {
+		if index == len(a.interceptors) {
+			return a.processingFn(msg, a)
+		}
+		return a.interceptors[index](msg, a, a.State(), func(msg f.Message) (any, error) {
+			return call(index+1, msg)
+		})
+	}
+
+	result, err := call(0, msg)
+	if result == nil {
+		var zero T
+		return zero, err
+	}
+	state, ok := result.(T)
+	if !ok {
+		return a.State(), fmt.Errorf("%w: %T", f.ErrorInterceptedState, result)
+	}
+	return state, err
+}
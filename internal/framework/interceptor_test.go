@@ -0,0 +1,108 @@
+package framework_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func stop(t *testing.T, actor f.ActorRef) {
+	t.Helper()
+
+	stopCompleted, err := actor.Stop()
+	assert.NilError(t, err)
+	<-stopCompleted
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Log("Interceptors test suite")
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	recording := func(probe testkit.TestProbe, name string) f.Interceptor {
+		return func(msg f.Message, self f.ActorRef, state any, next f.Next) (any, error) {
+			probe.Deliver(name+" before", self)
+			rv, err := next(msg)
+			probe.Deliver(name+" after", self)
+			return rv, err
+		}
+	}
+
+	t.Run("Chain the interceptors", func(t *testing.T) {
+		t.Log("Should run the system interceptors, then the actor interceptors, around the processing")
+
+		probe := testkit.NewTestProbe(t)
+		system := b.NewActorSystem("intercepted", b.WithInterceptors(recording(probe, "system")))
+		doubling := func(msg f.Message, self f.ActorRef, state any, next f.Next) (any, error) {
+			rv, err := next(msg)
+			return rv.(int) * 2, err
+		}
+
+		counter, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[int]) (int, error) {
+			probe.Deliver("processing", self)
+			return self.State() + msg.Payload().(int), nil
+		}, 1, b.WithSystem(system), b.WithInterceptors(recording(probe, "actor"), doubling))
+		assert.NilError(t, err)
+
+		assert.NilError(t, counter.Deliver(2, nil))
+		probe.ExpectMsg("system before")
+		probe.ExpectMsg("actor before")
+		probe.ExpectMsg("processing")
+		probe.ExpectMsg("actor after")
+		probe.ExpectMsg("system after")
+		stop(t, counter)
+		assert.Equal(t, counter.State(), 6)
+	})
+
+	t.Run("Short-circuit the processing", func(t *testing.T) {
+		t.Log("Should skip the processing and report the error of the interceptor to the failure handling")
+
+		errForbidden := errors.New("forbidden")
+		probe := testkit.NewTestProbe(t)
+		guarded, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[int]) (int, error) {
+			return self.State() + 1, probe.Deliver(msg.Payload(), self)
+		}, 0, b.WithInterceptors(func(msg f.Message, self f.ActorRef, state any, next f.Next) (any, error) {
+			if msg.Payload() == "intruder" {
+				return state, errForbidden
+			}
+			return next(msg)
+		}))
+		assert.NilError(t, err)
+
+		assert.NilError(t, guarded.Deliver("intruder", nil))
+		assert.NilError(t, guarded.Deliver("member", nil))
+		probe.ExpectMsg("member")
+		stop(t, guarded)
+		assert.Equal(t, guarded.State(), 1)
+		assert.ErrorIs(t, guarded.(f.Inspectable).Stats().LastError, errForbidden)
+	})
+
+	t.Run("Recover from panics", func(t *testing.T) {
+		t.Log("Should turn a panic into a processing error and keep the actor running")
+
+		probe := testkit.NewTestProbe(t)
+		fragile, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[int]) (int, error) {
+			if msg.Payload() == "panic" {
+				panic("boom")
+			}
+			return self.State() + 1, probe.Deliver(msg.Payload(), self)
+		}, 0, b.WithInterceptors(b.RecoverInterceptor()))
+		assert.NilError(t, err)
+
+		assert.NilError(t, fragile.Deliver("panic", nil))
+		assert.NilError(t, fragile.Deliver("ok", nil))
+		probe.ExpectMsg("ok")
+		assert.Equal(t, fragile.Status(), f.ActorStatusRunning)
+		stop(t, fragile)
+		assert.Equal(t, fragile.State(), 1)
+		assert.ErrorIs(t, fragile.(f.Inspectable).Stats().LastError, f.ErrorProcessingPanic)
+	})
+}
@@ -0,0 +1,53 @@
+package framework
+
+import (
+	"sort"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// parent is implemented by the actors exposing their children.
+type parent interface {
+	Children() []f.ActorRef
+}
+
+// Inspect walks the actor hierarchies from the given roots.
+func Inspect(roots ...f.ActorRef) f.ActorTree {
+	rv := make(f.ActorTree, 0, len(roots))
+	for _, root := range roots {
+		rv = append(rv, inspect(root))
+	}
+	return rv
+}
+
+func inspect(ref f.ActorRef) f.ActorInfo {
+	address := ref.Address()
+	rv := f.ActorInfo{
+		Address: address.String(),
+		Status:  ref.Status().String(),
+	}
+
+	if inspectable, ok := ref.(f.Inspectable); ok {
+		stats := inspectable.Stats()
+		rv.MailboxDepth = stats.MailboxDepth
+		rv.MailboxCapacity = stats.MailboxCapacity
+		rv.Policy = stats.Policy.String()
+		rv.Processed = stats.Processed
+		rv.Uptime = stats.Uptime
+		if stats.LastError != nil {
+			rv.LastError = stats.LastError.Error()
+		}
+	}
+
+	if withChildren, ok := ref.(parent); ok {
+		children := withChildren.Children()
+		sort.Slice(children, func(i, j int) bool {
+			left, right := children[i].Address(), children[j].Address()
+			return left.String() < right.String()
+		})
+		for _, child := range children {
+			rv.Children = append(rv.Children, inspect(child))
+		}
+	}
+	return rv
+}
@@ -0,0 +1,124 @@
+package framework_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func TestActorIntrospection(t *testing.T) {
+	t.Log("Actor introspection test suite")
+
+	var failingFn f.ProcessingFn[noState] = func(msg f.Message, self f.Actor[noState]) (noState, error) {
+		if msg.Payload() == "fail" {
+			return self.State(), errors.New("boom")
+		}
+		return self.State(), nil
+	}
+
+	t.Run("Walk the system tree", func(t *testing.T) {
+		t.Log("Should report the figures of the roots of the system and of their children")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		dispatcher := testkit.NewDeterministicDispatcher(1)
+		system := b.NewActorSystem("introspection", b.WithClock(clock), b.WithDispatcher(dispatcher))
+
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+		root, err := framework.NewActor(*address, failingFn, noState{}, b.WithSystem(system), f.MailboxConfig{Capacity: 8})
+		assert.NilError(t, err)
+		child, err := framework.NewActorWithParent(failingFn, noState{}, root)
+		assert.NilError(t, err)
+
+		assert.NilError(t, root.Deliver("ok", nil))
+		assert.NilError(t, root.Deliver("fail", nil))
+		assert.NilError(t, child.Deliver("ok", nil))
+		dispatcher.RunUntilIdle()
+		assert.NilError(t, root.Deliver("pending", nil))
+		clock.Advance(time.Minute)
+
+		tree := b.InspectSystem(system)
+		assert.Equal(t, len(tree), 1)
+		info := tree[0]
+		assert.Equal(t, info.Address, actorURI)
+		assert.Equal(t, info.Status, "running")
+		assert.Equal(t, info.MailboxDepth, 1)
+		assert.Equal(t, info.MailboxCapacity, 8)
+		assert.Equal(t, info.Policy, "block")
+		assert.Equal(t, info.Processed, uint64(2))
+		assert.Equal(t, info.LastError, "boom")
+		assert.Equal(t, info.Uptime, time.Minute)
+		assert.Equal(t, len(info.Children), 1)
+		assert.Equal(t, info.Children[0].Processed, uint64(1))
+
+		rendered, err := tree.JSON()
+		assert.NilError(t, err)
+		decoded := f.ActorTree{}
+		assert.NilError(t, json.Unmarshal(rendered, &decoded))
+		assert.DeepEqual(t, decoded, tree)
+
+		lines := strings.Split(strings.TrimSpace(tree.String()), "\n")
+		assert.Equal(t, len(lines), 2)
+		assert.Assert(t, strings.HasPrefix(lines[0], actorURI+" [running] mailbox=1/8 policy=block processed=2"))
+		childAddress := child.Address()
+		assert.Assert(t, strings.HasPrefix(lines[1], "  "+childAddress.String()))
+	})
+	t.Run("Forget the stopped roots", func(t *testing.T) {
+		t.Log("Should no longer report a root once stopped")
+
+		system := b.NewActorSystem("introspection")
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+		root, err := framework.NewActor(*address, failingFn, noState{}, b.WithSystem(system))
+		assert.NilError(t, err)
+		assert.Equal(t, len(system.Roots()), 1)
+
+		done, err := root.Stop()
+		assert.NilError(t, err)
+		<-done
+		assert.Equal(t, len(b.InspectSystem(system)), 0)
+	})
+
+	t.Run("Inspect while the tree changes", func(t *testing.T) {
+		t.Log("Should inspect the children while they are spawned and cropped concurrently")
+
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+		root, err := framework.NewActor(*address, failingFn, noState{})
+		assert.NilError(t, err)
+		defer stop(t, root)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 50 {
+				child, err := framework.NewActorWithParent(failingFn, noState{}, root)
+				if !assert.Check(t, err) {
+					return
+				}
+				_, err = root.Crop(child.Address())
+				assert.Check(t, err)
+			}
+		}()
+
+		for {
+			select {
+			case <-done:
+				assert.Equal(t, len(framework.Inspect(root)[0].Children), 0)
+				return
+			default:
+				assert.Assert(t, len(framework.Inspect(root)[0].Children) <= 1)
+			}
+		}
+	})
+}
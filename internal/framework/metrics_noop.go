@@ -0,0 +1,25 @@
+package framework
+
+import (
+	"net/url"
+	"time"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticNoopMetricsAssertion f.Metrics = (*noopMetrics)(nil)
+
+// noopMetrics is used when an actor is created without metrics.
+type noopMetrics struct{}
+
+func (noopMetrics) ActorStarted(address url.URL) {}
+
+func (noopMetrics) ActorStopped(address url.URL) {}
+
+func (noopMetrics) MessageEnqueued(address url.URL, policy f.BackpressurePolicy, depth int) {}
+
+func (noopMetrics) MessageDropped(address url.URL, policy f.BackpressurePolicy) {}
+
+func (noopMetrics) MessageRejected(address url.URL, policy f.BackpressurePolicy) {}
+
+func (noopMetrics) MessageProcessed(address url.URL, elapsed time.Duration, depth int, err error) {}
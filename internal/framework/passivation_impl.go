@@ -0,0 +1,445 @@
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticPassivatingActorAssertion f.PassivatingActor[any] = (*passivatingActor[any])(nil)
+var staticInspectablePassivatingActorAssertion f.Inspectable = (*passivatingActor[any])(nil)
+var staticRestartablePassivatingActorAssertion f.Restartable = (*passivatingActor[any])(nil)
+
+type stashedMessage struct {
+	msg  any
+	from c.Addressable
+}
+
+type passivatingActor[T any] struct {
+	lock *sync.Mutex
+
+	address     url.URL
+	factory     f.ActorFactory[T]
+	passivation f.PassivationConfig[T]
+	options     []f.ActorOption
+	addressBook r.AddressBook
+	clock       f.Clock
+	logger      *slog.Logger
+
+	incarnation  f.Actor[T]
+	lastActivity time.Time
+	idleTimer    f.Timer
+	passivating  bool
+	stash        []stashedMessage
+	// activation is closed once the incarnation being created outside the lock is in place
+	activation chan struct{}
+	// delivering counts the messages being delivered to the incarnation, waited for before stopping it
+	delivering *sync.WaitGroup
+
+	stopped       bool
+	stopCompleted chan bool
+	watchers      map[url.URL]f.ActorRef
+}
+
+// NewPassivatingActor creates a new actor reference incarnating the actor on demand and passivating it when idle.
+//
+// No incarnation is created until the first message is delivered.
+func NewPassivatingActor[T any](
+	address url.URL,
+	factory f.ActorFactory[T],
+	passivation f.PassivationConfig[T],
+	options ...f.ActorOption,
+) (f.PassivatingActor[T], error) {
+	if address.Scheme != "actor" {
+		return nil, f.ErrorInvalidActorAddress
+	}
+	if factory == nil {
+		return nil, errors.New("missing actor factory")
+	}
+
+	config := NewActorConfig(options...)
+	rv := &passivatingActor[T]{
+		lock: &sync.Mutex{},
+
+		address:     address,
+		factory:     factory,
+		passivation: passivation,
+		options:     options,
+		addressBook: config.AddressBook,
+		clock:       config.Clock,
+		logger:      config.Logger.With(f.LogAttrActorAddress, address.String()),
+
+		delivering:    &sync.WaitGroup{},
+		stopCompleted: make(chan bool, 1),
+		watchers:      make(map[url.URL]f.ActorRef),
+	}
+
+	// the reference, not its incarnations, stays registered while passivated
+	if rv.addressBook != nil {
+		rv.options = append(append([]f.ActorOption(nil), options...), f.ActorOptionFn(func(config *f.ActorConfig) {
+			config.AddressBook = nil
+		}))
+		if err := rv.addressBook.Register(rv); err != nil {
+			return nil, fmt.Errorf("failed to register actor: %w", err)
+		}
+	}
+	return rv, nil
+}
+
+// Address returns the actor's address.
+func (p *passivatingActor[T]) Address() url.URL {
+	return p.address
+}
+
+// Deliver delivers a message to the incarnation, creating it when the actor is passivated.
+func (p *passivatingActor[T]) Deliver(msg any, from c.Addressable) error {
+	for {
+		p.lock.Lock()
+		if p.stopped {
+			p.lock.Unlock()
+			return fmt.Errorf("failed to deliver message: %w", f.ErrorActorNotRunning)
+		}
+		if p.passivating {
+			p.stash = append(p.stash, stashedMessage{msg: msg, from: from})
+			p.lock.Unlock()
+			return nil
+		}
+		if activation := p.activation; activation != nil {
+			p.lock.Unlock()
+			<-activation
+			continue
+		}
+		if p.incarnation == nil || p.incarnation.Status() != f.ActorStatusRunning {
+			if err := p.activate(); err != nil {
+				return fmt.Errorf("failed to activate actor [%v]: %w", p.address.String(), err)
+			}
+			continue
+		}
+		incarnation := p.incarnation
+		p.lastActivity = p.clock.Now()
+		// The passivation waits for the message to reach the mailbox before the final drain
+		p.delivering.Add(1)
+		p.lock.Unlock()
+
+		err := incarnation.Deliver(msg, from)
+		p.delivering.Done()
+		if !errors.Is(err, f.ErrorActorNotRunning) {
+			return err
+		}
+	}
+}
+
+// Send sends a message to the destination on behalf of the actor.
+func (p *passivatingActor[T]) Send(msg any, destination c.Transport) error {
+	return destination.Deliver(msg, p)
+}
+
+// Stop passivates the actor for good, no further incarnation is created.
+func (p *passivatingActor[T]) Stop() (chan bool, error) {
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		return nil, fmt.Errorf("cannot stop actor: %w", f.ErrorActorNotRunning)
+	}
+	p.stopped = true
+	p.lock.Unlock()
+
+	if err := p.Passivate(); err != nil {
+		p.logger.Error("state not stored on stop", f.LogAttrError, err)
+	}
+
+	p.lock.Lock()
+	watchers := make([]f.ActorRef, 0, len(p.watchers))
+	for _, watcher := range p.watchers {
+		watchers = append(watchers, watcher)
+	}
+	clear(p.watchers)
+	p.lock.Unlock()
+
+	for _, watcher := range watchers {
+		if err := watcher.Deliver(f.Terminated{Address: p.address}, p); err != nil {
+			watcherAddress := watcher.Address()
+			p.logger.Debug("termination not delivered to watcher", "watcher", watcherAddress.String(), f.LogAttrError, err)
+		}
+	}
+
+	p.lock.Lock()
+	addressBook := p.addressBook
+	p.lock.Unlock()
+	if addressBook != nil {
+		if registered, found := addressBook.Resolve(p.address); found && registered == c.Addressable(p) {
+			if err := addressBook.Unregister(p.address); err != nil {
+				p.logger.Debug("actor not unregistered", f.LogAttrError, err)
+			}
+		}
+	}
+
+	p.stopCompleted <- true
+	return p.stopCompleted, nil
+}
+
+// Status returns the actor's status, running until stopped even while passivated.
+func (p *passivatingActor[T]) Status() f.ActorStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopped {
+		return f.ActorStatusIdle
+	}
+	return f.ActorStatusRunning
+}
+
+// Append appends a child actor to the running incarnation.
+func (p *passivatingActor[T]) Append(child f.ActorRef) error {
+	incarnation, ok := p.active()
+	if !ok {
+		return fmt.Errorf("cannot append child to passivated actor: %w", f.ErrorActorNotRunning)
+	}
+	return incarnation.Append(child)
+}
+
+// Crop removes a child actor from the running incarnation.
+func (p *passivatingActor[T]) Crop(url url.URL) (f.ActorRef, error) {
+	incarnation, ok := p.active()
+	if !ok {
+		return nil, f.ErrorInvalidChildURL
+	}
+	return incarnation.Crop(url)
+}
+
+// GetParent returns no parent, passivating actors are roots.
+func (p *passivatingActor[T]) GetParent() (f.ActorRef, bool) {
+	return nil, false
+}
+
+// Watch registers a watcher to be notified when the actor is stopped, passivation excluded.
+func (p *passivatingActor[T]) Watch(watcher f.ActorRef) error {
+	p.lock.Lock()
+	if !p.stopped {
+		p.watchers[watcher.Address()] = watcher
+		p.lock.Unlock()
+		return nil
+	}
+	p.lock.Unlock()
+
+	return watcher.Deliver(f.Terminated{Address: p.address}, p)
+}
+
+// Unwatch removes a watcher.
+func (p *passivatingActor[T]) Unwatch(watcher f.ActorRef) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.watchers, watcher.Address())
+}
+
+// Active returns whether an incarnation of the actor is in memory.
+func (p *passivatingActor[T]) Active() bool {
+	_, ok := p.active()
+	return ok
+}
+
+// Passivate stops the current incarnation, storing its state.
+func (p *passivatingActor[T]) Passivate() error {
+	p.lock.Lock()
+	incarnation := p.incarnation
+	if incarnation == nil || p.passivating {
+		p.lock.Unlock()
+		return nil
+	}
+	p.incarnation = nil
+	p.passivating = true
+	if p.idleTimer != nil {
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	p.lock.Unlock()
+
+	p.delivering.Wait()
+	if stopCompleted, err := incarnation.Stop(); err == nil {
+		<-stopCompleted
+	}
+	err := p.save(incarnation.State())
+	p.logger.Debug("actor passivated")
+
+	p.lock.Lock()
+	p.passivating = false
+	stash := p.stash
+	p.stash = nil
+	p.lock.Unlock()
+
+	for _, stashed := range stash {
+		if err := p.Deliver(stashed.msg, stashed.from); err != nil {
+			p.logger.Debug("message stashed during passivation not delivered", f.LogAttrError, err)
+		}
+	}
+
+	return err
+}
+
+// State returns the state of the running incarnation, or the stored one while passivated.
+func (p *passivatingActor[T]) State() (T, bool) {
+	if incarnation, ok := p.active(); ok {
+		return incarnation.State(), true
+	}
+
+	var rv T
+	if p.passivation.Store == nil {
+		return rv, false
+	}
+	stored, found, err := p.passivation.Store.Load(p.address)
+	if err != nil || !found {
+		return rv, false
+	}
+	return stored, true
+}
+
+// Restart resets the running incarnation to the state it was activated with, nothing to do while passivated.
+func (p *passivatingActor[T]) Restart() error {
+	incarnation, ok := p.active()
+	if !ok {
+		return nil
+	}
+	if restartable, ok := incarnation.(f.Restartable); ok {
+		return restartable.Restart()
+	}
+	return nil
+}
+
+// Stats returns the runtime figures of the running incarnation, none while passivated.
+func (p *passivatingActor[T]) Stats() f.ActorStats {
+	incarnation, ok := p.active()
+	if !ok {
+		return f.ActorStats{}
+	}
+	if inspectable, ok := incarnation.(f.Inspectable); ok {
+		return inspectable.Stats()
+	}
+	return f.ActorStats{}
+}
+
+// Children returns the children of the running incarnation, none while passivated.
+func (p *passivatingActor[T]) Children() []f.ActorRef {
+	incarnation, ok := p.active()
+	if !ok {
+		return []f.ActorRef{}
+	}
+	return incarnation.Children()
+}
+
+// keptIn makes the actor unregister from the address book it was registered in by someone else once stopped.
+func (p *passivatingActor[T]) keptIn(addressBook r.AddressBook) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.addressBook == nil {
+		p.addressBook = addressBook
+	}
+}
+
+func (p *passivatingActor[T]) active() (f.Actor[T], bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.incarnation == nil || p.incarnation.Status() != f.ActorStatusRunning {
+		return nil, false
+	}
+	return p.incarnation, true
+}
+
+// activate creates the next incarnation outside the lock, the deliveries meanwhile wait for it.
+func (p *passivatingActor[T]) activate() error {
+	// Lock is already held by caller (Deliver method), and released on return
+	previous := p.incarnation
+	p.incarnation = nil
+	activation := make(chan struct{})
+	p.activation = activation
+	p.lock.Unlock()
+
+	incarnation, err := p.incarnate(previous)
+
+	p.lock.Lock()
+	p.activation = nil
+	close(activation)
+	if err != nil {
+		p.incarnation = previous
+		p.lock.Unlock()
+		return err
+	}
+	if p.stopped {
+		p.lock.Unlock()
+		if stopCompleted, err := incarnation.Stop(); err == nil {
+			<-stopCompleted
+		}
+		return nil
+	}
+	p.incarnation = incarnation
+	p.lastActivity = p.clock.Now()
+	if p.passivation.IdleTimeout > 0 {
+		p.idleTimer = p.clock.AfterFunc(p.passivation.IdleTimeout, p.checkIdle)
+	}
+	p.lock.Unlock()
+	p.logger.Debug("actor activated")
+
+	return nil
+}
+
+func (p *passivatingActor[T]) incarnate(previous f.Actor[T]) (f.Actor[T], error) {
+	if previous != nil {
+		// The incarnation stopped itself, keep its state for the next one
+		if err := p.save(previous.State()); err != nil {
+			return nil, err
+		}
+	}
+
+	processingFn, state, err := p.factory(p.address)
+	if err != nil {
+		return nil, err
+	}
+	if p.passivation.Store != nil {
+		stored, found, err := p.passivation.Store.Load(p.address)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			state = stored
+		}
+	}
+
+	return NewActor(p.address, processingFn, state, p.options...)
+}
+
+func (p *passivatingActor[T]) checkIdle() {
+	p.lock.Lock()
+	if p.incarnation == nil || p.passivating {
+		p.lock.Unlock()
+		return
+	}
+	if remaining := p.passivation.IdleTimeout - p.clock.Since(p.lastActivity); remaining > 0 {
+		p.idleTimer = p.clock.AfterFunc(remaining, p.checkIdle)
+		p.lock.Unlock()
+		return
+	}
+	p.lock.Unlock()
+
+	if err := p.Passivate(); err != nil {
+		p.logger.Error("state not stored on passivation", f.LogAttrError, err)
+	}
+}
+
+func (p *passivatingActor[T]) save(state T) error {
+	if p.passivation.Store == nil {
+		return nil
+	}
+	if err := p.passivation.Store.Save(p.address, state); err != nil {
+		return fmt.Errorf("failed to store the state of actor [%v]: %w", p.address.String(), err)
+	}
+	return nil
+}
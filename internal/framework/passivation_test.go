@@ -0,0 +1,198 @@
+package framework_test
+
+import (
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/internal/routing"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func newCounterFactory(probe testkit.TestProbe, incarnations *atomic.Int32) f.ActorFactory[int] {
+	return func(address url.URL) (f.ProcessingFn[int], int, error) {
+		incarnations.Add(1)
+		return func(msg f.Message, self f.Actor[int]) (int, error) {
+			count := self.State() + 1
+			return count, self.Send(count, probe)
+		}, 0, nil
+	}
+}
+
+func TestPassivatingActor(t *testing.T) {
+	t.Log("PassivatingActor test suite")
+
+	t.Run("Passivate when idle and reactivate on demand", func(t *testing.T) {
+		t.Log("Should stop the idle incarnation, store its state and rehydrate it for the next message")
+
+		address, err := url.Parse(actorURI + "/counter")
+		assert.NilError(t, err)
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		store := framework.NewMemoryStateStore[int]()
+		probe := testkit.NewTestProbe(t)
+		incarnations := &atomic.Int32{}
+
+		counter, err := framework.NewPassivatingActor(
+			*address,
+			newCounterFactory(probe, incarnations),
+			f.PassivationConfig[int]{IdleTimeout: time.Minute, Store: store},
+			b.WithClock(clock),
+		)
+		assert.NilError(t, err)
+		assert.Assert(t, !counter.Active())
+
+		addressBook := routing.NewAddressBook()
+		assert.NilError(t, addressBook.Register(counter))
+
+		assert.NilError(t, counter.Deliver("inc", probe))
+		assert.NilError(t, counter.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+		probe.ExpectMsg(2)
+
+		clock.Advance(30 * time.Second)
+		assert.NilError(t, counter.Deliver("inc", probe))
+		probe.ExpectMsg(3)
+		clock.Advance(45 * time.Second)
+		assert.Assert(t, counter.Active())
+
+		clock.Advance(15 * time.Second)
+		assert.Assert(t, !counter.Active())
+		stored, found, err := store.Load(*address)
+		assert.NilError(t, err)
+		assert.Assert(t, found)
+		assert.Equal(t, stored, 3)
+
+		resolved, found := addressBook.Resolve(*address)
+		assert.Assert(t, found)
+		assert.NilError(t, resolved.(f.ActorRef).Deliver("inc", probe))
+		probe.ExpectMsg(4)
+		assert.Assert(t, counter.Active())
+		assert.Equal(t, incarnations.Load(), int32(2))
+	})
+
+	t.Run("Restart from the factory state without store", func(t *testing.T) {
+		t.Log("Should start every incarnation from the factory state when no store is configured")
+
+		address, err := url.Parse(actorURI + "/volatile")
+		assert.NilError(t, err)
+
+		probe := testkit.NewTestProbe(t)
+		incarnations := &atomic.Int32{}
+		counter, err := framework.NewPassivatingActor(*address, newCounterFactory(probe, incarnations), f.PassivationConfig[int]{})
+		assert.NilError(t, err)
+
+		assert.NilError(t, counter.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+		assert.NilError(t, counter.Passivate())
+		_, found := counter.State()
+		assert.Assert(t, !found)
+
+		assert.NilError(t, counter.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+		assert.Equal(t, incarnations.Load(), int32(2))
+	})
+
+	t.Run("Deliver while passivating", func(t *testing.T) {
+		t.Log("Should process every message delivered concurrently with the passivations, activating outside the lock")
+
+		address, err := url.Parse(actorURI + "/racing")
+		assert.NilError(t, err)
+
+		activating, gate := make(chan struct{}, 1), make(chan struct{})
+		factory := func(address url.URL) (f.ProcessingFn[int], int, error) {
+			select {
+			case activating <- struct{}{}:
+			default:
+			}
+			<-gate
+			return func(msg f.Message, self f.Actor[int]) (int, error) {
+				return self.State() + 1, nil
+			}, 0, nil
+		}
+		store := framework.NewMemoryStateStore[int]()
+		counter, err := framework.NewPassivatingActor(*address, factory, f.PassivationConfig[int]{Store: store})
+		assert.NilError(t, err)
+
+		const senders, messages = 4, 100
+		delivered := &sync.WaitGroup{}
+		for range senders {
+			delivered.Add(1)
+			go func() {
+				defer delivered.Done()
+				for range messages {
+					assert.Check(t, counter.Deliver("inc", nil))
+				}
+			}()
+		}
+		// The activation waits for the factory without holding the actor
+		<-activating
+		inspected := make(chan bool)
+		go func() {
+			inspected <- counter.Active()
+		}()
+		select {
+		case active := <-inspected:
+			assert.Assert(t, !active)
+		case <-time.After(testkit.DefaultTimeout):
+			t.Fatal("actor held during the activation")
+		}
+		close(gate)
+
+		done, passivated := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(passivated)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					assert.Check(t, counter.Passivate())
+				}
+			}
+		}()
+		delivered.Wait()
+		close(done)
+		<-passivated
+
+		stopCompleted, err := counter.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		state, found := counter.State()
+		assert.Assert(t, found)
+		assert.Equal(t, state, senders*messages)
+	})
+
+	t.Run("Stop for good", func(t *testing.T) {
+		t.Log("Should notify the watchers and refuse further messages once stopped")
+
+		address, err := url.Parse(actorURI + "/stopped")
+		assert.NilError(t, err)
+
+		probe := testkit.NewTestProbe(t)
+		store := framework.NewMemoryStateStore[int]()
+		counter, err := framework.NewPassivatingActor(*address, newCounterFactory(probe, &atomic.Int32{}), f.PassivationConfig[int]{Store: store})
+		assert.NilError(t, err)
+		probe.WatchActor(counter)
+
+		assert.NilError(t, counter.Deliver("inc", probe))
+		probe.ExpectMsg(1)
+
+		stopCompleted, err := counter.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		probe.ExpectTerminated(counter)
+		assert.Equal(t, counter.Status(), f.ActorStatusIdle)
+		state, found := counter.State()
+		assert.Assert(t, found)
+		assert.Equal(t, state, 1)
+		assert.ErrorIs(t, counter.Deliver("inc", probe), f.ErrorActorNotRunning)
+	})
+}
@@ -0,0 +1,23 @@
+package framework
+
+import (
+	"log/slog"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// PipeTo runs the work on its own goroutine and delivers its outcome to the actor as a Piped message.
+//
+// The actor keeps processing its mailbox while the work runs, its state is only updated when the outcome is processed.
+func PipeTo[R any](self c.Transport, work func() (R, error)) {
+	from, _ := self.(c.Addressable)
+	go func() {
+		value, err := work()
+		if err := self.Deliver(f.Piped[R]{Value: value, Err: err}, from); err != nil {
+			if scoped, ok := self.(interface{ Logger() *slog.Logger }); ok {
+				scoped.Logger().Debug("piped result not delivered", f.LogAttrError, err)
+			}
+		}
+	}()
+}
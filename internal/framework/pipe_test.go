@@ -0,0 +1,62 @@
+package framework_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func TestPipeTo(t *testing.T) {
+	t.Log("Pipe to self test suite")
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	t.Run("Pipe the outcome of slow work", func(t *testing.T) {
+		t.Log("Should keep processing the mailbox while the work runs and receive its outcome as a message")
+
+		errUnavailable := errors.New("unavailable")
+		release := make(chan struct{})
+		probe := testkit.NewTestProbe(t)
+		fetcher, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[int]) (int, error) {
+			switch payload := msg.Payload().(type) {
+			case string:
+				framework.PipeTo(self, func() (string, error) {
+					<-release
+					if payload == "broken" {
+						return "", errUnavailable
+					}
+					return "fetched " + payload, nil
+				})
+				return self.State() + 1, probe.Deliver("requested", self)
+			case f.Piped[string]:
+				assert.Equal(t, msg.Sender(), self.Address())
+				return self.State() - 1, probe.Deliver(payload, self)
+			default:
+				return self.State(), probe.Deliver(payload, self)
+			}
+		}, 0)
+		assert.NilError(t, err)
+
+		assert.NilError(t, fetcher.Deliver("page", nil))
+		probe.ExpectMsg("requested")
+		assert.NilError(t, fetcher.Deliver(42, nil))
+		probe.ExpectMsg(42)
+
+		close(release)
+		probe.ExpectMsg(f.Piped[string]{Value: "fetched page"})
+
+		assert.NilError(t, fetcher.Deliver("broken", nil))
+		probe.ExpectMsg("requested")
+		probe.ExpectMsg(f.Piped[string]{Err: errUnavailable})
+
+		stop(t, fetcher)
+		assert.Equal(t, fetcher.State(), 0)
+	})
+}
@@ -0,0 +1,98 @@
+package framework
+
+import (
+	"sync"
+	"time"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticSchedulerAssertion f.Scheduler = (*scheduler)(nil)
+var staticCancellableAssertion f.Cancellable = (*scheduled)(nil)
+
+type scheduler struct {
+	clock f.Clock
+}
+
+// NewScheduler creates a scheduler timing the deliveries with the given clock.
+func NewScheduler(clock f.Clock) f.Scheduler {
+	if clock == nil {
+		clock = NewSystemClock()
+	}
+	return &scheduler{clock: clock}
+}
+
+// ScheduleOnce delivers a message after the given delay.
+func (s *scheduler) ScheduleOnce(delay time.Duration, msg any, destination c.Transport, from c.Addressable) f.Cancellable {
+	rv := &scheduled{lock: &sync.Mutex{}}
+	rv.setTimer(s.clock.AfterFunc(delay, func() {
+		if rv.fire() {
+			destination.Deliver(msg, from)
+		}
+	}))
+
+	return rv
+}
+
+// ScheduleRepeatedly delivers a message after the initial delay and then at every interval, until cancelled.
+func (s *scheduler) ScheduleRepeatedly(initialDelay time.Duration, interval time.Duration, msg any, destination c.Transport, from c.Addressable) f.Cancellable {
+	rv := &scheduled{lock: &sync.Mutex{}, repeat: true}
+
+	var tick func()
+	tick = func() {
+		if !rv.fire() {
+			return
+		}
+		destination.Deliver(msg, from)
+		rv.setTimer(s.clock.AfterFunc(interval, tick))
+	}
+	rv.setTimer(s.clock.AfterFunc(initialDelay, tick))
+
+	return rv
+}
+
+type scheduled struct {
+	lock *sync.Mutex
+
+	timer     f.Timer
+	repeat    bool
+	fired     bool
+	cancelled bool
+}
+
+// Cancel stops the scheduled delivery.
+func (s *scheduled) Cancel() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.cancelled || s.fired {
+		return false
+	}
+	s.cancelled = true
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	return true
+}
+
+func (s *scheduled) setTimer(timer f.Timer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.timer = timer
+	if s.cancelled {
+		timer.Stop()
+	}
+}
+
+func (s *scheduled) fire() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.cancelled {
+		return false
+	}
+	s.fired = !s.repeat
+	return true
+}
@@ -0,0 +1,226 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticCoordinatedShutdownAssertion f.CoordinatedShutdown = (*coordinatedShutdown)(nil)
+
+const defaultShutdownTimeout = 10 * time.Second
+
+type shutdownTask struct {
+	name    string
+	timeout time.Duration
+	task    f.ShutdownTask
+}
+
+type coordinatedShutdown struct {
+	lock *sync.Mutex
+
+	phases  []f.ShutdownPhase
+	timeout time.Duration
+	clock   f.Clock
+	logger  *slog.Logger
+
+	tasks   map[string][]shutdownTask
+	started bool
+	err     error
+	done    chan struct{}
+}
+
+// NewCoordinatedShutdown creates a coordinated shutdown with the given phases.
+func NewCoordinatedShutdown(config f.ShutdownConfig) (f.CoordinatedShutdown, error) {
+	phases := config.Phases
+	if len(phases) == 0 {
+		phases = f.DefaultShutdownPhases
+	}
+	tasks := make(map[string][]shutdownTask, len(phases))
+	for _, phase := range phases {
+		if _, found := tasks[phase.Name]; found {
+			return nil, fmt.Errorf("duplicate shutdown phase [%s]", phase.Name)
+		}
+		tasks[phase.Name] = nil
+	}
+
+	actorConfig := NewActorConfig(config.Options...)
+	rv := &coordinatedShutdown{
+		lock: &sync.Mutex{},
+
+		phases:  append([]f.ShutdownPhase(nil), phases...),
+		timeout: config.Timeout,
+		clock:   actorConfig.Clock,
+		logger:  actorConfig.Logger.With("component", "shutdown"),
+
+		tasks: tasks,
+		done:  make(chan struct{}),
+	}
+	if rv.timeout <= 0 {
+		rv.timeout = defaultShutdownTimeout
+	}
+	return rv, nil
+}
+
+// NewStopActorsTask creates a shutdown task stopping the actors and waiting for their termination.
+func NewStopActorsTask(actors func() []f.ActorRef) f.ShutdownTask {
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, actor := range actors() {
+			stopCompleted, err := actor.Stop()
+			if err != nil {
+				if !errors.Is(err, f.ErrorActorNotRunning) {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			select {
+			case <-stopCompleted:
+			case <-ctx.Done():
+				address := actor.Address()
+				return errors.Join(append(errs, fmt.Errorf("actor [%v] not stopped: %w", address.String(), ctx.Err()))...)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// AddTask registers a task into a phase.
+func (s *coordinatedShutdown) AddTask(phase string, name string, timeout time.Duration, task f.ShutdownTask) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.started {
+		return fmt.Errorf("cannot add task [%s]: %w", name, f.ErrorShutdownStarted)
+	}
+	tasks, found := s.tasks[phase]
+	if !found {
+		return errors.Join(f.ErrorUnknownPhase, fmt.Errorf("phase [%s] of task [%s] does not exist", phase, name))
+	}
+	s.tasks[phase] = append(tasks, shutdownTask{name: name, timeout: timeout, task: task})
+	return nil
+}
+
+// Run runs the phases in order, once.
+func (s *coordinatedShutdown) Run(reason string) error {
+	s.lock.Lock()
+	if s.started {
+		s.lock.Unlock()
+		<-s.done
+		return s.err
+	}
+	s.started = true
+	s.lock.Unlock()
+
+	s.logger.Info("shutdown started", "reason", reason)
+	var errs []error
+	for _, phase := range s.phases {
+		if err := s.runPhase(phase); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.err = errors.Join(errs...)
+	s.logger.Info("shutdown completed", "reason", reason, f.LogAttrError, s.err)
+
+	close(s.done)
+	return s.err
+}
+
+// Done returns a channel closed when the shutdown completed.
+func (s *coordinatedShutdown) Done() <-chan struct{} {
+	return s.done
+}
+
+// NotifySignals runs the shutdown when the process receives one of the signals, the first one only.
+func (s *coordinatedShutdown) NotifySignals(signals ...os.Signal) func() {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	received := make(chan os.Signal, 1)
+	released := make(chan struct{})
+	once := &sync.Once{}
+	release := func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(released)
+		})
+	}
+
+	signal.Notify(received, signals...)
+	go func() {
+		select {
+		case sig := <-received:
+			// A second signal terminates the process as by default, e.g. when the shutdown hangs
+			release()
+			s.Run("signal " + sig.String())
+		case <-released:
+		}
+	}()
+
+	return release
+}
+
+func (s *coordinatedShutdown) runPhase(phase f.ShutdownPhase) error {
+	s.lock.Lock()
+	tasks := s.tasks[phase.Name]
+	s.lock.Unlock()
+
+	timeout := phase.Timeout
+	if timeout <= 0 {
+		timeout = s.timeout
+	}
+
+	errs := make([]error, len(tasks))
+	wg := &sync.WaitGroup{}
+	for index, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[index] = s.runTask(phase.Name, task, timeout)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (s *coordinatedShutdown) runTask(phase string, task shutdownTask, timeout time.Duration) error {
+	if task.timeout > 0 {
+		timeout = task.timeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := s.clock.AfterFunc(timeout, cancel)
+	defer timer.Stop()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- task.task(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+	}
+	if ctx.Err() != nil {
+		// only the timer cancels the context before the task returns
+		err = f.ErrorShutdownTimeout
+	}
+	if err == nil {
+		return nil
+	}
+
+	s.logger.Warn("shutdown task failed", "phase", phase, "task", task.name, f.LogAttrError, err)
+	return fmt.Errorf("task [%s] of phase [%s]: %w", task.name, phase, err)
+}
@@ -0,0 +1,171 @@
+package framework_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type journal struct {
+	lock    sync.Mutex
+	entries []string
+}
+
+func (j *journal) task(entry string) f.ShutdownTask {
+	return func(ctx context.Context) error {
+		j.lock.Lock()
+		defer j.lock.Unlock()
+		j.entries = append(j.entries, entry)
+		return nil
+	}
+}
+
+func (j *journal) read() []string {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return append([]string(nil), j.entries...)
+}
+
+func TestCoordinatedShutdown(t *testing.T) {
+	t.Log("Coordinated shutdown test suite")
+
+	t.Run("Run the phases in order", func(t *testing.T) {
+		t.Log("Should run every task of a phase before the next phase and stop the actor systems")
+
+		system := b.NewActorSystem("shutdown")
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+		worker, err := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return self.State(), nil
+		}, noState{}, b.WithSystem(system))
+		assert.NilError(t, err)
+
+		shutdown, err := framework.NewCoordinatedShutdown(f.ShutdownConfig{})
+		assert.NilError(t, err)
+		entries := &journal{}
+		assert.NilError(t, shutdown.AddTask(f.PhaseCloseTransports, "transports", 0, entries.task("transports")))
+		assert.NilError(t, shutdown.AddTask(f.PhaseStopInput, "listener", 0, entries.task("input")))
+		assert.NilError(t, shutdown.AddTask(f.PhaseStopWorkers, "system", 0, b.StopSystemTask(system)))
+		assert.NilError(t, shutdown.AddTask(f.PhaseStopWorkers, "workers", 0, entries.task("workers")))
+		assert.ErrorIs(t, shutdown.AddTask("unknown", "lost", 0, entries.task("lost")), f.ErrorUnknownPhase)
+
+		assert.NilError(t, shutdown.Run("test"))
+		<-shutdown.Done()
+		assert.DeepEqual(t, entries.read(), []string{"input", "workers", "transports"})
+		assert.Equal(t, worker.Status(), f.ActorStatusIdle)
+		assert.Equal(t, len(system.Roots()), 0)
+
+		assert.ErrorIs(t, shutdown.AddTask(f.PhaseStopInput, "late", 0, entries.task("late")), f.ErrorShutdownStarted)
+		assert.NilError(t, shutdown.Run("again"))
+		assert.Equal(t, len(entries.read()), 3)
+	})
+
+	t.Run("Report the failures and the time outs", func(t *testing.T) {
+		t.Log("Should time out the tasks on the clock, report the failures and carry on with the next phases")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		shutdown, err := framework.NewCoordinatedShutdown(f.ShutdownConfig{
+			Phases:  []f.ShutdownPhase{{Name: "drain", Timeout: time.Second}, {Name: "close"}},
+			Options: []f.ActorOption{b.WithClock(clock)},
+		})
+		assert.NilError(t, err)
+
+		errBroken := errors.New("broken")
+		entries := &journal{}
+		stuck := make(chan struct{})
+		assert.NilError(t, shutdown.AddTask("drain", "stuck", 0, func(ctx context.Context) error {
+			close(stuck)
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+		assert.NilError(t, shutdown.AddTask("close", "broken", 0, func(ctx context.Context) error {
+			return errBroken
+		}))
+		assert.NilError(t, shutdown.AddTask("close", "close", 0, entries.task("closed")))
+
+		result := make(chan error, 1)
+		go func() { result <- shutdown.Run("test") }()
+
+		<-stuck
+		clock.Advance(time.Second)
+
+		err = <-result
+		assert.ErrorIs(t, err, f.ErrorShutdownTimeout)
+		assert.ErrorIs(t, err, errBroken)
+		assert.ErrorContains(t, err, "task [stuck] of phase [drain]")
+		assert.DeepEqual(t, entries.read(), []string{"closed"})
+
+		_, err = framework.NewCoordinatedShutdown(f.ShutdownConfig{Phases: []f.ShutdownPhase{{Name: "drain"}, {Name: "drain"}}})
+		assert.ErrorContains(t, err, "duplicate shutdown phase")
+	})
+
+	t.Run("Shut down on signal", func(t *testing.T) {
+		t.Log("Should run the shutdown when the process receives a hooked signal")
+
+		shutdown, err := framework.NewCoordinatedShutdown(f.ShutdownConfig{})
+		assert.NilError(t, err)
+		entries := &journal{}
+		assert.NilError(t, shutdown.AddTask(f.PhaseStopInput, "listener", 0, entries.task("input")))
+
+		release := shutdown.NotifySignals(os.Interrupt)
+		defer release()
+
+		process, err := os.FindProcess(os.Getpid())
+		assert.NilError(t, err)
+		assert.NilError(t, process.Signal(os.Interrupt))
+
+		select {
+		case <-shutdown.Done():
+		case <-time.After(testkit.DefaultTimeout):
+			t.Fatal("shutdown not run on signal")
+		}
+		assert.DeepEqual(t, entries.read(), []string{"input"})
+	})
+
+	t.Run("Terminate on a second signal", func(t *testing.T) {
+		t.Log("Should release the signals once the shutdown starts, so that a second signal terminates a hung process")
+
+		if os.Getenv("SHUTDOWN_HUNG_PROCESS") == "1" {
+			shutdown, err := framework.NewCoordinatedShutdown(f.ShutdownConfig{})
+			assert.NilError(t, err)
+			started := make(chan struct{})
+			assert.NilError(t, shutdown.AddTask(f.PhaseStopInput, "hung", time.Hour, func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}))
+			shutdown.NotifySignals(os.Interrupt)
+
+			process, err := os.FindProcess(os.Getpid())
+			assert.NilError(t, err)
+			assert.NilError(t, process.Signal(os.Interrupt))
+			<-started
+			assert.NilError(t, process.Signal(os.Interrupt))
+			time.Sleep(testkit.DefaultTimeout)
+			return
+		}
+
+		cmd := exec.Command(os.Args[0], "-test.run", "^TestCoordinatedShutdown$/^Terminate_on_a_second_signal$")
+		cmd.Env = append(os.Environ(), "SHUTDOWN_HUNG_PROCESS=1")
+		err := cmd.Run()
+		var exitErr *exec.ExitError
+		assert.Assert(t, errors.As(err, &exitErr), "hung process not terminated: %v", err)
+		status, ok := exitErr.Sys().(syscall.WaitStatus)
+		assert.Assert(t, ok)
+		assert.Assert(t, status.Signaled())
+		assert.Equal(t, status.Signal(), syscall.SIGINT)
+	})
+}
@@ -0,0 +1,42 @@
+package framework
+
+import (
+	"net/url"
+	"sync"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticMemoryStateStoreAssertion f.StateStore[any] = (*memoryStateStore[any])(nil)
+
+type memoryStateStore[T any] struct {
+	lock *sync.Mutex
+
+	states map[url.URL]T
+}
+
+// NewMemoryStateStore creates a state store keeping the states in memory.
+func NewMemoryStateStore[T any]() f.StateStore[T] {
+	return &memoryStateStore[T]{
+		lock:   &sync.Mutex{},
+		states: make(map[url.URL]T),
+	}
+}
+
+// Save stores the state of the actor.
+func (s *memoryStateStore[T]) Save(address url.URL, state T) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.states[address] = state
+	return nil
+}
+
+// Load retrieves the stored state of the actor.
+func (s *memoryStateStore[T]) Load(address url.URL) (T, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, found := s.states[address]
+	return state, found, nil
+}
@@ -0,0 +1,168 @@
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/morphy76/lang-actor/internal/routing"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticActorSystemAssertion f.ActorSystem = (*actorSystem)(nil)
+var staticLifecycleTrackerAssertion lifecycleTracker = (*actorSystem)(nil)
+
+type actorSystem struct {
+	lock *sync.Mutex
+
+	name        string
+	logger      *slog.Logger
+	options     []f.ActorOption
+	addressBook r.AddressBook
+	kinds       map[string]f.GrainActivator
+	roots       map[url.URL]f.ActorRef
+	subscribers map[chan f.LifecycleEvent]struct{}
+}
+
+// lifecycleTracker is implemented by the actor systems keeping track of the lifecycle of their actors.
+type lifecycleTracker interface {
+	adopt(root f.ActorRef)
+	release(root f.ActorRef)
+	publish(event f.LifecycleEvent)
+}
+
+// Name returns the name of the actor system.
+func (s *actorSystem) Name() string {
+	return s.name
+}
+
+// Logger returns the logger scoped with the system name.
+func (s *actorSystem) Logger() *slog.Logger {
+	return s.logger
+}
+
+// Options returns the actor options applied to every actor of the system.
+func (s *actorSystem) Options() []f.ActorOption {
+	return s.options
+}
+
+// AddressBook returns the address book shared by the actors of the system.
+func (s *actorSystem) AddressBook() r.AddressBook {
+	return s.addressBook
+}
+
+// RegisterKind registers the activator of the grains of a kind.
+func (s *actorSystem) RegisterKind(kind string, activator f.GrainActivator) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, found := s.kinds[kind]; found {
+		return errors.Join(f.ErrorGrainKindRegistered, fmt.Errorf("kind [%s] already registered in system [%s]", kind, s.name))
+	}
+	s.kinds[kind] = activator
+	return nil
+}
+
+// Kind returns the activator of the grains of a kind.
+func (s *actorSystem) Kind(kind string) (f.GrainActivator, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	activator, found := s.kinds[kind]
+	return activator, found
+}
+
+// Roots returns the running actors of the system without a parent.
+func (s *actorSystem) Roots() []f.ActorRef {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	rv := make([]f.ActorRef, 0, len(s.roots))
+	for _, root := range s.roots {
+		rv = append(rv, root)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		left, right := rv[i].Address(), rv[j].Address()
+		return left.String() < right.String()
+	})
+	return rv
+}
+
+func (s *actorSystem) adopt(root f.ActorRef) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.roots[root.Address()] = root
+}
+
+func (s *actorSystem) release(root f.ActorRef) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if current, found := s.roots[root.Address()]; found && current == root {
+		delete(s.roots, root.Address())
+	}
+}
+
+// Subscribe streams the lifecycle events of the actors of the system.
+func (s *actorSystem) Subscribe(buffer int) (<-chan f.LifecycleEvent, func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	rv := make(chan f.LifecycleEvent, max(buffer, 0))
+	s.subscribers[rv] = struct{}{}
+	return rv, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		if _, found := s.subscribers[rv]; found {
+			delete(s.subscribers, rv)
+			close(rv)
+		}
+	}
+}
+
+func (s *actorSystem) publish(event f.LifecycleEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// NewActorSystem creates a new actor system with the given name and shared actor options.
+func NewActorSystem(name string, options ...f.ActorOption) f.ActorSystem {
+	config := f.ActorConfig{}
+	for _, option := range options {
+		option.Apply(&config)
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	addressBook := routing.NewAddressBook()
+	if config.Clock != nil {
+		addressBook = routing.NewAddressBook(config.Clock)
+	}
+
+	return &actorSystem{
+		lock: &sync.Mutex{},
+
+		name:        name,
+		logger:      logger.With(f.LogAttrSystem, name),
+		options:     options,
+		addressBook: addressBook,
+		kinds:       make(map[string]f.GrainActivator),
+		roots:       make(map[url.URL]f.ActorRef),
+		subscribers: make(map[chan f.LifecycleEvent]struct{}),
+	}
+}
@@ -0,0 +1,77 @@
+package framework
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// tokenEpsilon absorbs the rounding of the refill, so that a token is available once its delay elapsed.
+const tokenEpsilon = 1e-9
+
+// tokenBucket grants up to burst tokens at once, refilled at rate tokens per second.
+type tokenBucket struct {
+	lock *sync.Mutex
+
+	clock    f.Clock
+	rate     float64
+	burst    float64
+	overflow f.ThrottleOverflow
+
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(config f.ThrottleConfig, clock f.Clock) *tokenBucket {
+	if config.Rate <= 0 {
+		return nil
+	}
+
+	burst := float64(max(config.Burst, 1))
+	return &tokenBucket{
+		lock: &sync.Mutex{},
+
+		clock:    clock,
+		rate:     config.Rate,
+		burst:    burst,
+		overflow: config.Overflow,
+
+		tokens: burst,
+		last:   clock.Now(),
+	}
+}
+
+// take consumes a token when one is available.
+func (b *tokenBucket) take() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refill()
+	if b.tokens < 1-tokenEpsilon {
+		return false
+	}
+	b.tokens = max(b.tokens-1, 0)
+	return true
+}
+
+// delay returns the time until a token is available, zero when one is available now.
+func (b *tokenBucket) delay() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refill()
+	if b.tokens >= 1-tokenEpsilon {
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / b.rate * float64(time.Second)))
+}
+
+func (b *tokenBucket) refill() {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.last = now
+}
@@ -0,0 +1,110 @@
+package framework_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func TestThrottledMailbox(t *testing.T) {
+	t.Log("Throttled mailbox test suite")
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	forwardFn := func(probe testkit.TestProbe) f.ProcessingFn[noState] {
+		return func(msg f.Message, self f.Actor[noState]) (noState, error) {
+			return self.State(), probe.Deliver(msg.Payload(), self)
+		}
+	}
+
+	t.Run("Queue the excess messages", func(t *testing.T) {
+		t.Log("Should process a burst at once and the following messages at the rate of the throttle")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		mailbox := b.NewMailboxConfigWithThrottle(b.NewMailboxConfigWithBlockPolicy(10), 1, 2, f.ThrottleOverflowQueue)
+		actor, err := framework.NewActor(*address, forwardFn(probe), noState{}, mailbox, b.WithClock(clock))
+		assert.NilError(t, err)
+		defer stop(t, actor)
+
+		for i := range 4 {
+			assert.NilError(t, actor.Deliver(i, nil))
+		}
+		probe.ExpectMsg(0)
+		probe.ExpectMsg(1)
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		clock.Advance(time.Second)
+		probe.ExpectMsg(2)
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		clock.Advance(time.Second)
+		probe.ExpectMsg(3)
+	})
+
+	t.Run("Release the dispatcher while throttled", func(t *testing.T) {
+		t.Log("Should give the worker back while waiting for the rate and resume on the clock")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		dispatcher := testkit.NewDeterministicDispatcher(1)
+		mailbox := b.NewMailboxConfigWithThrottle(b.NewMailboxConfigWithBlockPolicy(10), 2, 1, f.ThrottleOverflowQueue)
+		actor, err := framework.NewActor(*address, forwardFn(probe), noState{}, mailbox, b.WithClock(clock), b.WithDispatcher(dispatcher))
+		assert.NilError(t, err)
+
+		for i := range 3 {
+			assert.NilError(t, actor.Deliver(i, nil))
+		}
+		dispatcher.RunUntilIdle()
+		probe.ExpectMsg(0)
+		assert.Equal(t, clock.PendingTimers(), 1)
+		assert.Equal(t, actor.(f.Inspectable).Stats().MailboxDepth, 2)
+
+		clock.Advance(500 * time.Millisecond)
+		dispatcher.RunUntilIdle()
+		probe.ExpectMsg(1)
+
+		clock.Advance(500 * time.Millisecond)
+		dispatcher.RunUntilIdle()
+		probe.ExpectMsg(2)
+		assert.Equal(t, clock.PendingTimers(), 0)
+	})
+
+	t.Run("Drop or reject the excess messages", func(t *testing.T) {
+		t.Log("Should discard or refuse the messages exceeding the rate")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+
+		dropping, err := framework.NewActor(*address, forwardFn(probe), noState{},
+			b.NewMailboxConfigWithThrottle(b.NewMailboxConfigWithBlockPolicy(10), 1, 1, f.ThrottleOverflowDrop), b.WithClock(clock))
+		assert.NilError(t, err)
+		defer stop(t, dropping)
+
+		assert.NilError(t, dropping.Deliver("kept", nil))
+		assert.NilError(t, dropping.Deliver("dropped", nil))
+		probe.ExpectMsg("kept")
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		rejecting, err := framework.NewActor(*address, forwardFn(probe), noState{},
+			b.NewMailboxConfigWithThrottle(b.NewMailboxConfigWithBlockPolicy(10), 1, 1, f.ThrottleOverflowReject), b.WithClock(clock))
+		assert.NilError(t, err)
+		defer stop(t, rejecting)
+
+		assert.NilError(t, rejecting.Deliver("accepted", nil))
+		assert.ErrorIs(t, rejecting.Deliver("rejected", nil), f.ErrorThrottled)
+		probe.ExpectMsg("accepted")
+
+		clock.Advance(time.Second)
+		assert.NilError(t, rejecting.Deliver("accepted again", nil))
+		probe.ExpectMsg("accepted again")
+	})
+}
@@ -0,0 +1,91 @@
+package framework
+
+import (
+	"fmt"
+	"net/url"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticTypedRefAssertion f.TypedRef[any] = (*typedRef[any])(nil)
+var staticTypedActorAssertion f.TypedActor[any, any] = (*typedActor[any, any])(nil)
+
+type typedRef[M any] struct {
+	ref f.ActorRef
+}
+
+type typedActor[T any, M any] struct {
+	*actor[T]
+}
+
+// NewTypedRef adapts an untyped actor reference to accept the messages of a single type.
+func NewTypedRef[M any](ref f.ActorRef) f.TypedRef[M] {
+	return &typedRef[M]{ref: ref}
+}
+
+// NewTypedProcessingFn adapts a typed processing function to an untyped one.
+//
+// The payloads of other types fail the processing with ErrorUnexpectedMessage, leaving the state unchanged.
+func NewTypedProcessingFn[T any, M any](processingFn f.TypedProcessingFn[T, M]) f.ProcessingFn[T] {
+	return func(msg f.Message, self f.Actor[T]) (T, error) {
+		typed, ok := msg.Payload().(M)
+		if !ok {
+			return self.State(), fmt.Errorf("%w: %T", f.ErrorUnexpectedMessage, msg.Payload())
+		}
+		return processingFn(typed, self)
+	}
+}
+
+// NewTypedActor creates a new actor with the given address, accepting the messages of a single type.
+func NewTypedActor[T any, M any](
+	address url.URL,
+	processingFn f.TypedProcessingFn[T, M],
+	initialState T,
+	options ...f.ActorOption,
+) (f.TypedActor[T, M], error) {
+	if address.Scheme != "actor" {
+		return nil, f.ErrorInvalidActorAddress
+	}
+
+	rv, err := newActor(address, NewTypedProcessingFn(processingFn), initialState, nil, NewActorConfig(options...))
+	if err != nil {
+		return nil, err
+	}
+	return &typedActor[T, M]{actor: rv}, nil
+}
+
+// Address returns the address of the referenced actor.
+func (r *typedRef[M]) Address() url.URL {
+	return r.ref.Address()
+}
+
+// Tell delivers a message to the referenced actor without a sender.
+func (r *typedRef[M]) Tell(msg M) error {
+	return r.ref.Deliver(msg, nil)
+}
+
+// TellFrom delivers a message to the referenced actor on behalf of a sender.
+func (r *typedRef[M]) TellFrom(msg M, from c.Addressable) error {
+	return r.ref.Deliver(msg, from)
+}
+
+// Untyped returns the underlying reference.
+func (r *typedRef[M]) Untyped() f.ActorRef {
+	return r.ref
+}
+
+// Tell delivers a message to the actor without a sender.
+func (a *typedActor[T, M]) Tell(msg M) error {
+	return a.Deliver(msg, nil)
+}
+
+// TellFrom delivers a message to the actor on behalf of a sender.
+func (a *typedActor[T, M]) TellFrom(msg M, from c.Addressable) error {
+	return a.Deliver(msg, from)
+}
+
+// Ref returns the typed reference to the actor.
+func (a *typedActor[T, M]) Ref() f.TypedRef[M] {
+	return NewTypedRef[M](a.actor)
+}
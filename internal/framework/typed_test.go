@@ -0,0 +1,63 @@
+package framework_test
+
+import (
+	"net/url"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type increment struct {
+	amount int
+}
+
+func TestTypedActor(t *testing.T) {
+	t.Log("Typed actor test suite")
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	t.Run("Tell typed messages", func(t *testing.T) {
+		t.Log("Should process the messages told through the actor and its typed references")
+
+		probe := testkit.NewTestProbe(t)
+		counter, err := framework.NewTypedActor(*address, func(msg increment, self f.Actor[int]) (int, error) {
+			total := self.State() + msg.amount
+			return total, self.Send(total, probe)
+		}, 0)
+		assert.NilError(t, err)
+		defer counter.Stop()
+
+		assert.NilError(t, counter.Tell(increment{amount: 1}))
+		probe.ExpectMsg(1)
+
+		ref := framework.NewTypedRef[increment](counter.Ref().Untyped())
+		assert.Equal(t, ref.Address(), counter.Address())
+		assert.NilError(t, ref.TellFrom(increment{amount: 2}, probe))
+		probe.ExpectMsg(3)
+	})
+
+	t.Run("Reject untyped messages", func(t *testing.T) {
+		t.Log("Should fail the processing of the payloads of another type and keep the state")
+
+		probe := testkit.NewTestProbe(t)
+		counter, err := framework.NewTypedActor(*address, func(msg increment, self f.Actor[int]) (int, error) {
+			total := self.State() + msg.amount
+			return total, self.Send(total, probe)
+		}, 0)
+		assert.NilError(t, err)
+		defer counter.Stop()
+
+		assert.NilError(t, counter.Ref().Untyped().Deliver("increment", nil))
+		assert.NilError(t, counter.Tell(increment{amount: 5}))
+		probe.ExpectMsg(5)
+
+		inspectable, ok := counter.(f.Inspectable)
+		assert.Assert(t, ok)
+		assert.ErrorIs(t, inspectable.Stats().LastError, f.ErrorUnexpectedMessage)
+	})
+}
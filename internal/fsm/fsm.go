@@ -0,0 +1,205 @@
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	sm "github.com/morphy76/lang-actor/pkg/fsm"
+)
+
+var staticBuilderAssertion sm.Builder[any] = (*builder[any])(nil)
+var staticMachineAssertion sm.Machine[any] = (*machine[any])(nil)
+
+type builder[D any] struct {
+	initial   string
+	data      D
+	handlers  map[string]sm.Handler[D]
+	timeouts  map[string]time.Duration
+	hooks     []sm.TransitionHook[D]
+	unhandled sm.Handler[D]
+}
+
+// NewBuilder creates the builder of a finite state machine starting in the given state with the given data.
+func NewBuilder[D any](initial string, data D) sm.Builder[D] {
+	return &builder[D]{
+		initial:  initial,
+		data:     data,
+		handlers: make(map[string]sm.Handler[D]),
+		timeouts: make(map[string]time.Duration),
+	}
+}
+
+// When sets the handler of a state.
+func (b *builder[D]) When(state string, handler sm.Handler[D]) sm.Builder[D] {
+	b.handlers[state] = handler
+	return b
+}
+
+// Timeout sends a StateTimeout to the machine when no message arrives within the duration while in the state.
+func (b *builder[D]) Timeout(state string, timeout time.Duration) sm.Builder[D] {
+	b.timeouts[state] = timeout
+	return b
+}
+
+// OnTransition adds a hook called on every change of state.
+func (b *builder[D]) OnTransition(hook sm.TransitionHook[D]) sm.Builder[D] {
+	b.hooks = append(b.hooks, hook)
+	return b
+}
+
+// WhenUnhandled sets the fallback of the messages not handled by the current state.
+func (b *builder[D]) WhenUnhandled(handler sm.Handler[D]) sm.Builder[D] {
+	b.unhandled = handler
+	return b
+}
+
+// Build validates the description of the machine.
+func (b *builder[D]) Build() (sm.Machine[D], error) {
+	if _, found := b.handlers[b.initial]; !found {
+		return nil, errors.Join(sm.ErrorUnknownState, fmt.Errorf("initial state [%s] has no handler", b.initial))
+	}
+	for state := range b.timeouts {
+		if _, found := b.handlers[state]; !found {
+			return nil, errors.Join(sm.ErrorUnknownState, fmt.Errorf("timed out state [%s] has no handler", state))
+		}
+	}
+
+	return &machine[D]{
+		initial:   sm.State[D]{Name: b.initial, Data: b.data},
+		handlers:  b.handlers,
+		timeouts:  b.timeouts,
+		hooks:     b.hooks,
+		unhandled: b.unhandled,
+	}, nil
+}
+
+type machine[D any] struct {
+	initial   sm.State[D]
+	handlers  map[string]sm.Handler[D]
+	timeouts  map[string]time.Duration
+	hooks     []sm.TransitionHook[D]
+	unhandled sm.Handler[D]
+}
+
+// InitialState returns the state of a new FSM actor.
+func (m *machine[D]) InitialState() sm.State[D] {
+	return m.initial
+}
+
+// ProcessingFn returns the processing function of a new FSM actor.
+func (m *machine[D]) ProcessingFn(options ...f.ActorOption) f.ProcessingFn[sm.State[D]] {
+	return m.newRuntime(options...).process
+}
+
+// Spawn creates a new FSM actor in the initial state.
+func (m *machine[D]) Spawn(address url.URL, options ...f.ActorOption) (f.Actor[sm.State[D]], error) {
+	runtime := m.newRuntime(options...)
+	rv, err := framework.NewActor(address, runtime.process, m.initial, options...)
+	if err != nil {
+		return nil, err
+	}
+	// The timeout of the initial state is armed by the actor itself, its runtime is not shared
+	if err := rv.Deliver(armSignal{}, rv); err != nil {
+		if stopCompleted, stopErr := rv.Stop(); stopErr == nil {
+			<-stopCompleted
+		}
+		return nil, fmt.Errorf("failed to start the timeout of the initial state: %w", err)
+	}
+	return rv, nil
+}
+
+func (m *machine[D]) newRuntime(options ...f.ActorOption) *runtime[D] {
+	return &runtime[D]{
+		machine:   m,
+		scheduler: framework.NewScheduler(framework.NewActorConfig(options...).Clock),
+	}
+}
+
+// armSignal starts the timeout of the initial state of a spawned actor.
+type armSignal struct{}
+
+// runtime drives the machine of a single actor, its processing is never concurrent.
+type runtime[D any] struct {
+	machine   *machine[D]
+	scheduler f.Scheduler
+	timeout   f.Cancellable
+	armed     bool
+}
+
+func (r *runtime[D]) process(msg f.Message, self f.Actor[sm.State[D]]) (sm.State[D], error) {
+	current := self.State()
+	if _, ok := msg.Payload().(armSignal); ok {
+		if !r.armed {
+			r.arm(self, current)
+		}
+		return current, nil
+	}
+	if timeout, ok := msg.Payload().(sm.StateTimeout); ok && (timeout.Generation != current.Generation || timeout.State != current.Name) {
+		// Stale timeout, another message arrived meanwhile
+		return current, nil
+	}
+
+	transition, err := r.machine.handlers[current.Name](msg, current.Data, self)
+	if err == nil && transition.IsUnhandled() {
+		transition, err = r.fallback(msg, current, self)
+	}
+	if err != nil {
+		return current, err
+	}
+
+	next := sm.State[D]{
+		Name:       current.Name,
+		Data:       transition.Data(),
+		Generation: current.Generation + 1,
+	}
+	if target := transition.Next(); target != "" {
+		if _, found := r.machine.handlers[target]; !found {
+			return current, errors.Join(sm.ErrorUnknownState, fmt.Errorf("transition from [%s] to unknown state [%s]", current.Name, target))
+		}
+		next.Name = target
+	}
+
+	if next.Name != current.Name {
+		self.Logger().Debug("FSM transition", "from", current.Name, "to", next.Name)
+		for _, hook := range r.machine.hooks {
+			hook(current.Name, next.Name, next.Data)
+		}
+	}
+	r.arm(self, next)
+
+	return next, nil
+}
+
+func (r *runtime[D]) fallback(msg f.Message, current sm.State[D], self f.Actor[sm.State[D]]) (sm.Transition[D], error) {
+	if r.machine.unhandled != nil {
+		transition, err := r.machine.unhandled(msg, current.Data, self)
+		if err != nil || !transition.IsUnhandled() {
+			return transition, err
+		}
+	}
+
+	self.Logger().Warn(
+		"FSM message unhandled",
+		"state", current.Name,
+		f.LogAttrMessageType, fmt.Sprintf("%T", msg.Payload()),
+	)
+	return sm.Stay(current.Data), nil
+}
+
+func (r *runtime[D]) arm(self f.ActorRef, state sm.State[D]) {
+	r.armed = true
+	if r.timeout != nil {
+		r.timeout.Cancel()
+		r.timeout = nil
+	}
+
+	timeout, found := r.machine.timeouts[state.Name]
+	if !found || timeout <= 0 {
+		return
+	}
+	r.timeout = r.scheduler.ScheduleOnce(timeout, sm.StateTimeout{State: state.Name, Generation: state.Generation}, self, self)
+}
@@ -0,0 +1,192 @@
+package fsm_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/fsm"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	sm "github.com/morphy76/lang-actor/pkg/fsm"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type code string
+
+type transition struct {
+	From string
+	To   string
+}
+
+func newDoor(t *testing.T, probe testkit.TestProbe) sm.Machine[int] {
+	locked := func(msg f.Message, attempts int, self f.Actor[sm.State[int]]) (sm.Transition[int], error) {
+		switch payload := msg.Payload().(type) {
+		case code:
+			if payload == "1234" {
+				return sm.Goto("open", 0), nil
+			}
+			return sm.Stay(attempts + 1), nil
+		default:
+			return sm.Unhandled[int](), nil
+		}
+	}
+	open := func(msg f.Message, attempts int, self f.Actor[sm.State[int]]) (sm.Transition[int], error) {
+		switch msg.Payload().(type) {
+		case sm.StateTimeout:
+			return sm.Goto("locked", attempts), nil
+		case code:
+			return sm.Stay(attempts), probe.Deliver("already open", self)
+		default:
+			return sm.Unhandled[int](), nil
+		}
+	}
+
+	machine, err := fsm.NewBuilder("locked", 0).
+		When("locked", locked).
+		When("open", open).
+		Timeout("open", 10*time.Second).
+		OnTransition(func(from string, to string, attempts int) {
+			probe.Deliver(transition{From: from, To: to}, probe)
+		}).
+		Build()
+	assert.NilError(t, err)
+	return machine
+}
+
+func TestFSM(t *testing.T) {
+	t.Log("FSM test suite")
+
+	address, err := url.Parse("actor://door")
+	assert.NilError(t, err)
+
+	t.Run("Transition and time out", func(t *testing.T) {
+		t.Log("Should move across the states, call the hooks and time out on the actor clock")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		door, err := newDoor(t, probe).Spawn(*address, b.WithClock(clock))
+		assert.NilError(t, err)
+		assert.Equal(t, door.State().Name, "locked")
+
+		assert.NilError(t, door.Deliver(code("0000"), probe))
+		assert.NilError(t, door.Deliver(code("1234"), probe))
+		probe.ExpectMsg(transition{From: "locked", To: "open"})
+		assert.Equal(t, door.State().Name, "open")
+
+		clock.Advance(5 * time.Second)
+		assert.NilError(t, door.Deliver(code("1234"), probe))
+		probe.ExpectMsg("already open")
+		clock.Advance(5 * time.Second)
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		clock.Advance(5 * time.Second)
+		probe.ExpectMsg(transition{From: "open", To: "locked"})
+		assert.Equal(t, door.State().Name, "locked")
+	})
+
+	t.Run("Time out the initial state", func(t *testing.T) {
+		t.Log("Should start the timeout of the initial state along with the spawned actor")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		machine, err := fsm.NewBuilder("waiting", 0).
+			When("waiting", func(msg f.Message, data int, self f.Actor[sm.State[int]]) (sm.Transition[int], error) {
+				if _, ok := msg.Payload().(sm.StateTimeout); ok {
+					return sm.Goto("expired", data), nil
+				}
+				return sm.Stay(data + 1), nil
+			}).
+			When("expired", func(msg f.Message, data int, self f.Actor[sm.State[int]]) (sm.Transition[int], error) {
+				return sm.Stay(data), nil
+			}).
+			Timeout("waiting", time.Second).
+			OnTransition(func(from string, to string, data int) {
+				probe.Deliver(transition{From: from, To: to}, probe)
+			}).
+			Build()
+		assert.NilError(t, err)
+
+		actor, err := machine.Spawn(*address, b.WithClock(clock))
+		assert.NilError(t, err)
+		for clock.PendingTimers() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(time.Second)
+		probe.ExpectMsg(transition{From: "waiting", To: "expired"})
+		assert.Equal(t, actor.State().Data, 0)
+	})
+
+	t.Run("Fall back on unhandled messages", func(t *testing.T) {
+		t.Log("Should hand the unhandled messages to the fallback, logging them otherwise")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		probe := testkit.NewTestProbe(t)
+		door, err := newDoor(t, probe).Spawn(*address, b.WithLogger(logger))
+		assert.NilError(t, err)
+		probe.WatchActor(door)
+		assert.NilError(t, door.Deliver(42, probe))
+
+		stopCompleted, err := door.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		probe.ExpectTerminated(door)
+		assert.Assert(t, strings.Contains(buffer.String(), "FSM message unhandled"))
+
+		fallback := make(chan any, 1)
+		machine, err := fsm.NewBuilder("idle", "").
+			When("idle", func(msg f.Message, data string, self f.Actor[sm.State[string]]) (sm.Transition[string], error) {
+				return sm.Unhandled[string](), nil
+			}).
+			When("busy", func(msg f.Message, data string, self f.Actor[sm.State[string]]) (sm.Transition[string], error) {
+				return sm.Stay(data), nil
+			}).
+			WhenUnhandled(func(msg f.Message, data string, self f.Actor[sm.State[string]]) (sm.Transition[string], error) {
+				fallback <- msg.Payload()
+				return sm.Goto("busy", "from fallback"), nil
+			}).
+			Build()
+		assert.NilError(t, err)
+
+		actor, err := machine.Spawn(*address)
+		assert.NilError(t, err)
+		assert.NilError(t, actor.Deliver("anything", probe))
+		assert.Equal(t, <-fallback, "anything")
+		stopCompleted, err = actor.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		assert.Equal(t, actor.State().Name, "busy")
+		assert.Equal(t, actor.State().Data, "from fallback")
+	})
+
+	t.Run("Validate the states", func(t *testing.T) {
+		t.Log("Should reject machines and transitions referring to unknown states")
+
+		_, err := fsm.NewBuilder("missing", 0).Build()
+		assert.ErrorIs(t, err, sm.ErrorUnknownState)
+
+		machine, err := fsm.NewBuilder("start", 0).
+			When("start", func(msg f.Message, data int, self f.Actor[sm.State[int]]) (sm.Transition[int], error) {
+				return sm.Goto("nowhere", data), nil
+			}).
+			Build()
+		assert.NilError(t, err)
+
+		var buffer bytes.Buffer
+		actor, err := machine.Spawn(*address, b.WithLogger(slog.New(slog.NewJSONHandler(&buffer, nil))))
+		assert.NilError(t, err)
+		assert.NilError(t, actor.Deliver("go", actor))
+		stopCompleted, err := actor.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		assert.Equal(t, actor.State().Name, "start")
+		assert.Assert(t, strings.Contains(buffer.String(), "unknown FSM state"))
+	})
+}
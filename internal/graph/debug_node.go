@@ -51,15 +51,15 @@ func NewDebugNode(forGraph g.Graph, nameParts ...string) (g.Node, error) {
 	}
 
 	taskFn := func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
-		fmt.Println("\n==========================================")
-		fmt.Printf("Debug node [%+v] received message [%+v]\n", self.Address(), msg)
-		fmt.Println("---------------------------------")
-		fmt.Printf("System config [%+v]\n", self.State().GraphConfig())
+		attrs := []any{
+			f.LogAttrMessageType, fmt.Sprintf("%T", msg.Payload()),
+			"message", fmt.Sprintf("%+v", msg.Payload()),
+			"config", fmt.Sprintf("%+v", self.State().GraphConfig()),
+		}
 		if self.State().GraphState() != nil {
-			fmt.Println("---------------------------------")
-			fmt.Printf("Graph status [%+v]\n", self.State().GraphState().Unwrap())
+			attrs = append(attrs, "state", fmt.Sprintf("%+v", self.State().GraphState().Unwrap()))
 		}
-		fmt.Println("==========================================")
+		self.Logger().Info("debug node received message", attrs...)
 		self.State().ProceedOntoRoute() <- g.WhateverOutcome
 		return self.State(), nil
 	}
@@ -62,12 +62,14 @@ func NewForkJoingNode[C g.NodeRef](forGraph g.Graph, transient bool, processingF
 		return nil, err
 	}
 
+	options, _ := nodeOptions(forGraph)
 	for _, processingFn := range processingFns {
 		childState := g.BasicNodeRefBuilder[C](forGraph, baseNode, childOutcomes)
 		framework.NewActorWithParent(
 			processingFn,
 			childState,
 			baseNode.actor,
+			options...,
 		)
 	}
 
@@ -4,7 +4,9 @@ import (
 	"net/url"
 	"sync"
 
+	"github.com/morphy76/lang-actor/internal/framework"
 	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
 	g "github.com/morphy76/lang-actor/pkg/graph"
 	r "github.com/morphy76/lang-actor/pkg/routing"
 )
@@ -19,6 +21,9 @@ type graph struct {
 	config      g.Configuration
 	state       *stateWrapper
 	addressBook r.AddressBook
+	options     []f.ActorOption
+	actorConfig f.ActorConfig
+	settings    g.Settings
 
 	stateChangedCh chan g.State
 }
@@ -28,6 +33,11 @@ func (g *graph) Register(addressable c.Addressable) error {
 	return g.addressBook.Register(addressable)
 }
 
+// Unregister removes the registration of the given URL.
+func (g *graph) Unregister(address url.URL) error {
+	return g.addressBook.Unregister(address)
+}
+
 // Resolve resolves the given URL to a framework.Addressable.
 func (g *graph) Resolve(address url.URL) (c.Addressable, bool) {
 	return g.addressBook.Resolve(address)
@@ -38,6 +48,16 @@ func (g *graph) Query(schema string, pathParts ...string) []c.Addressable {
 	return g.addressBook.Query(schema, pathParts...)
 }
 
+// Select selects the addressables of the graph matching a pattern.
+func (g *graph) Select(pattern string, from ...url.URL) (r.Selection, error) {
+	return g.addressBook.Select(pattern, from...)
+}
+
+// ResolveRelative resolves a path relative to an address of the graph.
+func (g *graph) ResolveRelative(from url.URL, path string) (c.Addressable, bool) {
+	return g.addressBook.ResolveRelative(from, path)
+}
+
 // State returns the current state of the graph.
 func (g *graph) State() g.State {
 	return g.state
@@ -51,3 +71,19 @@ func (g *graph) Config() g.Configuration {
 func (g *graph) StateChangedCh() <-chan g.State {
 	return g.stateChangedCh
 }
+
+// nodeOptions returns the actor options shared by the nodes of the given graph.
+func nodeOptions(forGraph g.Graph) ([]f.ActorOption, f.ActorConfig) {
+	if useGraph, ok := forGraph.(*graph); ok {
+		return useGraph.options, useGraph.actorConfig
+	}
+	return nil, framework.NewActorConfig()
+}
+
+// nodeSettings returns the graph settings shared by the nodes of the given graph.
+func nodeSettings(forGraph g.Graph) g.Settings {
+	if useGraph, ok := forGraph.(*graph); ok {
+		return useGraph.settings
+	}
+	return g.Settings{}
+}
@@ -1,11 +1,14 @@
 package graph
 
 import (
+	"log/slog"
 	"net/url"
 	"sync"
 
+	"github.com/morphy76/lang-actor/internal/framework"
 	"github.com/morphy76/lang-actor/internal/routing"
 	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
 	g "github.com/morphy76/lang-actor/pkg/graph"
 )
 
@@ -14,6 +17,7 @@ func NewGraph[T g.State, C g.Configuration](
 	graphName string,
 	initialState T,
 	config C,
+	options ...f.ActorOption,
 ) (g.Graph, error) {
 
 	graphURL, err := url.Parse("graph://" + graphName)
@@ -21,10 +25,22 @@ func NewGraph[T g.State, C g.Configuration](
 		return nil, err
 	}
 
+	settings := g.Settings{}
+	for _, option := range options {
+		if graphOption, ok := option.(g.GraphOption); ok {
+			graphOption.ApplyGraph(&settings)
+		}
+	}
+
+	actorConfig := framework.NewActorConfig(options...)
+	actorConfig.Logger = actorConfig.Logger.With(f.LogAttrGraph, graphURL.String())
+	useOptions := append(append([]f.ActorOption(nil), options...), withLogger(actorConfig.Logger))
+
 	stateChangedCh := make(chan g.State, 1000)
 	useState := &stateWrapper{
 		state:          initialState,
 		stateChangesCh: stateChangedCh,
+		logger:         actorConfig.Logger,
 
 		lock: &sync.Mutex{},
 	}
@@ -37,9 +53,18 @@ func NewGraph[T g.State, C g.Configuration](
 		config:      config,
 		state:       useState,
 		addressBook: routing.NewAddressBook(),
+		options:     useOptions,
+		actorConfig: actorConfig,
+		settings:    settings,
 
 		stateChangedCh: stateChangedCh,
 	}
 
 	return graph, nil
 }
+
+func withLogger(logger *slog.Logger) f.ActorOption {
+	return f.ActorOptionFn(func(config *f.ActorConfig) {
+		config.Logger = logger
+	})
+}
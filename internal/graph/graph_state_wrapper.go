@@ -2,6 +2,8 @@ package graph
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
 
 	g "github.com/morphy76/lang-actor/pkg/graph"
@@ -29,6 +31,7 @@ func NewStateWrapper(state g.State, stateChangesCh chan g.State) (*stateWrapper,
 
 		state:          state,
 		stateChangesCh: stateChangesCh,
+		logger:         slog.Default(),
 	}, nil
 }
 
@@ -37,6 +40,7 @@ type stateWrapper struct {
 
 	state          g.State
 	stateChangesCh chan g.State
+	logger         *slog.Logger
 }
 
 // MergeChange appends a new state to the graph and notifies the state changes channel.
@@ -52,7 +56,7 @@ func (s *stateWrapper) MergeChange(purpose any, value any) error {
 	case s.stateChangesCh <- s.state:
 	default:
 		// Channel is full, skip notification rather than blocking
-		// TODO In production, this could be logged as a warning
+		s.logger.Warn("state change notification skipped, channel is full", "purpose", fmt.Sprintf("%v", purpose))
 	}
 
 	return nil
@@ -1,11 +1,24 @@
 package graph_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"gotest.tools/v3/assert"
+
 	"github.com/morphy76/lang-actor/internal/graph"
+	"github.com/morphy76/lang-actor/internal/tracing"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
 	g "github.com/morphy76/lang-actor/pkg/graph"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+	tr "github.com/morphy76/lang-actor/pkg/tracing"
 )
 
 type graphState struct {
@@ -45,3 +58,231 @@ func TestNewGraph(t *testing.T) {
 		}
 	})
 }
+
+type recordingExporter struct {
+	lock  sync.Mutex
+	spans []tr.SpanData
+}
+
+func (e *recordingExporter) Export(spans []tr.SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestGraphTracing(t *testing.T) {
+	t.Log("Graph tracing test suite")
+
+	t.Run("Trace the nodes of a graph", func(t *testing.T) {
+		t.Log("Should create a span for each accepting node, chained in a single trace")
+
+		exporter := &recordingExporter{}
+		tracer := tracing.NewTracer(exporter)
+
+		initialState := graphState{stateAsMap: make(map[string]any)}
+		newGraph, err := graph.NewGraph(uuid.NewString(), initialState, make(map[string]any), b.WithTracer(tracer))
+		assert.NilError(t, err)
+
+		rootNode, err := graph.NewRootNode(newGraph)
+		assert.NilError(t, err)
+		endNode, err := graph.NewEndNode(newGraph)
+		assert.NilError(t, err)
+		assert.NilError(t, rootNode.OneWayRoute("end", endNode))
+
+		assert.NilError(t, rootNode.Accept(uuid.NewString()))
+		assert.NilError(t, tracer.Shutdown())
+
+		accepts := make(map[string]tr.SpanData)
+		for _, span := range exporter.spans {
+			for _, attr := range span.Attributes {
+				if attr.Key == tr.AttributeGraphNode {
+					accepts[attr.Value.(string)] = span
+				}
+			}
+		}
+		rootAddress, endAddress := rootNode.Address(), endNode.Address()
+		rootSpan, endSpan := accepts[rootAddress.String()], accepts[endAddress.String()]
+		assert.Equal(t, endSpan.Context.TraceID, rootSpan.Context.TraceID)
+		assert.Equal(t, endSpan.Parent, rootSpan.Context.SpanID)
+	})
+}
+
+func TestGraphLogging(t *testing.T) {
+	t.Log("Graph logging test suite")
+
+	t.Run("Log debug nodes to the graph logger", func(t *testing.T) {
+		t.Log("Should route the debug node output to the graph logger with the graph and node attributes")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		initialState := graphState{stateAsMap: make(map[string]any)}
+		newGraph, err := graph.NewGraph(uuid.NewString(), initialState, make(map[string]any), b.WithLogger(logger))
+		assert.NilError(t, err)
+
+		rootNode, err := graph.NewRootNode(newGraph)
+		assert.NilError(t, err)
+		debugNode, err := graph.NewDebugNode(newGraph)
+		assert.NilError(t, err)
+		endNode, err := graph.NewEndNode(newGraph)
+		assert.NilError(t, err)
+		assert.NilError(t, rootNode.OneWayRoute("debug", debugNode))
+		assert.NilError(t, debugNode.OneWayRoute("end", endNode))
+
+		assert.NilError(t, rootNode.Accept("hello"))
+
+		record := make(map[string]any)
+		assert.NilError(t, json.NewDecoder(&buffer).Decode(&record))
+		debugAddress := debugNode.Address()
+		assert.Equal(t, record["msg"], "debug node received message")
+		assert.Equal(t, record[f.LogAttrGraphNode], debugAddress.String())
+		assert.Equal(t, record[f.LogAttrMessageType], "string")
+		assert.Assert(t, record[f.LogAttrGraph] != nil)
+	})
+}
+
+func TestGraphNodeTimeout(t *testing.T) {
+	t.Log("Graph node timeout test suite")
+
+	t.Run("Time out a silent node", func(t *testing.T) {
+		t.Log("Should fail the accept once the node timeout elapses on the graph clock")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		initialState := graphState{stateAsMap: make(map[string]any)}
+		newGraph, err := graph.NewGraph(uuid.NewString(), initialState, make(map[string]any), b.WithClock(clock), b.WithNodeTimeout(time.Second))
+		assert.NilError(t, err)
+
+		address, err := url.Parse("graph://nodes/silent")
+		assert.NilError(t, err)
+		silentNode, err := graph.NewCustomNode(newGraph, address, func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+			return self.State(), nil
+		})
+		assert.NilError(t, err)
+
+		accepted := make(chan error, 1)
+		go func() {
+			accepted <- silentNode.Accept("hello")
+		}()
+
+		assert.Assert(t, clock.AwaitTimers(1, testkit.DefaultTimeout))
+		select {
+		case err := <-accepted:
+			t.Fatalf("accept completed before the timeout: %v", err)
+		default:
+		}
+
+		clock.Advance(time.Second)
+		err = <-accepted
+		assert.ErrorIs(t, err, g.ErrorNodeTimeout)
+	})
+
+	t.Run("Discard a late outcome", func(t *testing.T) {
+		t.Log("Should not route the next message by the outcome produced after the timeout")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		initialState := graphState{stateAsMap: make(map[string]any)}
+		newGraph, err := graph.NewGraph(uuid.NewString(), initialState, make(map[string]any), b.WithClock(clock), b.WithNodeTimeout(time.Second))
+		assert.NilError(t, err)
+
+		gate := make(chan struct{})
+		produced := make(chan struct{})
+		switchAddress, err := url.Parse("graph://nodes/switch")
+		assert.NilError(t, err)
+		switchNode, err := graph.NewCustomNode(newGraph, switchAddress, func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+			route := msg.Payload().(string)
+			if route == "left" {
+				<-gate
+			}
+			self.State().ProceedOntoRoute() <- route
+			if route == "left" {
+				close(produced)
+			}
+			return self.State(), nil
+		})
+		assert.NilError(t, err)
+
+		probe := testkit.NewTestProbe(t)
+		endNode, err := graph.NewEndNode(newGraph)
+		assert.NilError(t, err)
+		for _, route := range []string{"left", "right"} {
+			address, err := url.Parse("graph://nodes/" + route)
+			assert.NilError(t, err)
+			branch, err := graph.NewCustomNode(newGraph, address, func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+				self.State().ProceedOntoRoute() <- g.WhateverOutcome
+				return self.State(), probe.Deliver(route, nil)
+			})
+			assert.NilError(t, err)
+			assert.NilError(t, branch.OneWayRoute("end", endNode))
+			assert.NilError(t, switchNode.OneWayRoute(route, branch))
+		}
+
+		accepted := make(chan error, 1)
+		go func() {
+			accepted <- switchNode.Accept("left")
+		}()
+		assert.Assert(t, clock.AwaitTimers(1, testkit.DefaultTimeout))
+		clock.Advance(time.Second)
+		assert.ErrorIs(t, <-accepted, g.ErrorNodeTimeout)
+
+		close(gate)
+		<-produced
+		assert.NilError(t, switchNode.Accept("right"))
+		probe.ExpectMsg("right")
+		probe.ExpectNoMsg(20 * time.Millisecond)
+	})
+
+	t.Run("Discard an outcome produced while the next message waits", func(t *testing.T) {
+		t.Log("Should not route the next message by the late outcome produced while its own outcome is awaited")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		initialState := graphState{stateAsMap: make(map[string]any)}
+		newGraph, err := graph.NewGraph(uuid.NewString(), initialState, make(map[string]any), b.WithClock(clock), b.WithNodeTimeout(time.Second))
+		assert.NilError(t, err)
+
+		gate := make(chan struct{})
+		switchAddress, err := url.Parse("graph://nodes/switch")
+		assert.NilError(t, err)
+		switchNode, err := graph.NewCustomNode(newGraph, switchAddress, func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+			route := msg.Payload().(string)
+			if route == "left" {
+				<-gate
+			}
+			self.State().ProceedOntoRoute() <- route
+			return self.State(), nil
+		})
+		assert.NilError(t, err)
+
+		probe := testkit.NewTestProbe(t)
+		endNode, err := graph.NewEndNode(newGraph)
+		assert.NilError(t, err)
+		for _, route := range []string{"left", "right"} {
+			address, err := url.Parse("graph://nodes/" + route)
+			assert.NilError(t, err)
+			branch, err := graph.NewCustomNode(newGraph, address, func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+				self.State().ProceedOntoRoute() <- g.WhateverOutcome
+				return self.State(), probe.Deliver(route, nil)
+			})
+			assert.NilError(t, err)
+			assert.NilError(t, branch.OneWayRoute("end", endNode))
+			assert.NilError(t, switchNode.OneWayRoute(route, branch))
+		}
+
+		accepted := make(chan error, 1)
+		go func() {
+			accepted <- switchNode.Accept("left")
+		}()
+		assert.Assert(t, clock.AwaitTimers(1, testkit.DefaultTimeout))
+		clock.Advance(time.Second)
+		assert.ErrorIs(t, <-accepted, g.ErrorNodeTimeout)
+
+		go func() {
+			accepted <- switchNode.Accept("right")
+		}()
+		assert.Assert(t, clock.AwaitTimers(1, testkit.DefaultTimeout))
+		close(gate)
+		assert.NilError(t, <-accepted)
+		probe.ExpectMsg("right")
+		probe.ExpectNoMsg(20 * time.Millisecond)
+	})
+}
@@ -2,7 +2,9 @@ package graph
 
 import (
 	"net/url"
+	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/morphy76/lang-actor/internal/framework"
 	f "github.com/morphy76/lang-actor/pkg/framework"
@@ -21,8 +23,18 @@ func newNode[T g.NodeRef](
 		return nil, err
 	}
 
+	options, config := nodeOptions(forGraph)
+	logger := config.Logger.With(f.LogAttrGraphNode, address.String())
+	options = append(append([]f.ActorOption(nil), options...), withLogger(logger))
+
 	rv := &node{
 		lock:             &sync.Mutex{},
+		tracer:           config.Tracer,
+		logger:           logger,
+		clock:            config.Clock,
+		timeout:          nodeSettings(forGraph).NodeTimeout,
+		accepted:         &atomic.Uint64{},
+		generation:       &atomic.Uint64{},
 		edges:            make(map[string]edge, 0),
 		address:          address,
 		resolver:         forGraph,
@@ -34,8 +46,9 @@ func newNode[T g.NodeRef](
 
 	task, err := framework.NewActor(
 		*actorAddress,
-		taskFn,
+		markOutcomes(taskFn),
 		useRef,
+		options...,
 	)
 	if err != nil {
 		return nil, err
@@ -50,3 +63,18 @@ func newNode[T g.NodeRef](
 
 	return rv, nil
 }
+
+// markOutcomes precedes the outcomes of every message with the marker of the generation of its accept, the
+// messages not accepted by the node, such as the piped results, belong to the generation of the last accepted one.
+func markOutcomes[T g.NodeRef](taskFn f.ProcessingFn[T]) f.ProcessingFn[T] {
+	var generation uint64
+	return func(msg f.Message, self f.Actor[T]) (T, error) {
+		if accepted, found := msg.Metadata()[acceptGenerationKey]; found {
+			if parsed, err := strconv.ParseUint(accepted, 10, 64); err == nil {
+				generation = parsed
+			}
+		}
+		self.State().ProceedOntoRoute() <- generationMarker + strconv.FormatUint(generation, 10)
+		return taskFn(msg, self)
+	}
+}
@@ -3,17 +3,30 @@ package graph
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	c "github.com/morphy76/lang-actor/pkg/common"
 	f "github.com/morphy76/lang-actor/pkg/framework"
 	g "github.com/morphy76/lang-actor/pkg/graph"
 	r "github.com/morphy76/lang-actor/pkg/routing"
+	t "github.com/morphy76/lang-actor/pkg/tracing"
 )
 
 var staticNodeAssertion g.Node = (*node)(nil)
 
+const (
+	// acceptGenerationKey is the metadata carrying the generation of the accepted message to the node actor
+	acceptGenerationKey = "graph-accept-generation"
+	// generationMarker precedes the outcomes of a generation, it cannot be a route name
+	generationMarker = "\x00generation:"
+)
+
 type node struct {
 	lock *sync.Mutex
 
@@ -25,6 +38,14 @@ type node struct {
 	actor   f.ActorRef
 
 	nodeRef g.NodeRef
+	tracer  t.Tracer
+	logger  *slog.Logger
+	clock   f.Clock
+	timeout time.Duration
+	// accepted numbers the accepted messages, the outcomes of a message follow the marker of its generation
+	accepted *atomic.Uint64
+	// generation is the generation of the outcomes being read, the outcomes of the other ones are stale
+	generation *atomic.Uint64
 
 	multipleOutcomes bool
 }
@@ -118,36 +139,99 @@ func (r *node) ProceedOnRoute(name string, mex any) error {
 
 // Accept accepts a message and delivers it to the actor
 func (r *node) Accept(message any) error {
+	if r.tracer == nil {
+		return r.accept(message)
+	}
+
+	payload, metadata := c.Unwrap(message)
+	parent, _ := t.Extract(metadata)
+	actorAddress := r.actor.Address()
+	span := r.tracer.Start(
+		parent,
+		"graph.node.accept",
+		t.Attribute{Key: t.AttributeGraphNode, Value: r.address.String()},
+		t.Attribute{Key: t.AttributeActorAddress, Value: actorAddress.String()},
+	)
+	metadata = metadata.Clone()
+	t.Inject(span.Context(), metadata)
+
+	err := r.accept(c.Envelope{Payload: payload, Metadata: metadata})
+	span.End(err)
+	return err
+}
 
-	if err := r.actor.Deliver(message, r); err != nil {
+func (r *node) accept(message any) error {
+	generation := r.accepted.Add(1)
+	payload, metadata := c.Unwrap(message)
+	metadata = metadata.Clone()
+	metadata[acceptGenerationKey] = strconv.FormatUint(generation, 10)
+	if err := r.actor.Deliver(c.Envelope{Payload: payload, Metadata: metadata}, r); err != nil {
 		return fmt.Errorf("failed to deliver message to node [%v]: %w", r.Address(), err)
 	}
 
-	// TODO implement a timeout for the outcome channel
 	if r.multipleOutcomes {
 		for {
-			outcome := <-r.NodeRef().ProceedOntoRoute()
+			outcome, err := r.awaitOutcome(generation)
+			if err != nil {
+				return err
+			}
 			if outcome == g.SkipOutcome {
 				return nil
 			} else if outcome == g.WhateverOutcome {
-				r.ProceedOnAnyRoute(message)
+				r.logRoutingFailure(outcome, r.ProceedOnAnyRoute(message))
 				return nil
 			} else {
-				r.ProceedOnRoute(outcome, message)
+				r.logRoutingFailure(outcome, r.ProceedOnRoute(outcome, message))
 			}
 		}
 	} else {
-		outcome := <-r.NodeRef().ProceedOntoRoute()
+		outcome, err := r.awaitOutcome(generation)
+		if err != nil {
+			return err
+		}
 		if outcome != g.WhateverOutcome {
-			r.ProceedOnRoute(outcome, message)
+			r.logRoutingFailure(outcome, r.ProceedOnRoute(outcome, message))
 		} else {
-			r.ProceedOnAnyRoute(message)
+			r.logRoutingFailure(outcome, r.ProceedOnAnyRoute(message))
 		}
 	}
 
 	return nil
 }
 
+// awaitOutcome returns the next outcome of the given generation, dropping the ones produced after their accept timed out.
+func (r *node) awaitOutcome(generation uint64) (string, error) {
+	var expired <-chan time.Time
+	if r.timeout > 0 && r.clock != nil {
+		timer := r.clock.NewTimer(r.timeout)
+		defer timer.Stop()
+		expired = timer.C()
+	}
+
+	for {
+		select {
+		case outcome := <-r.NodeRef().ProceedOntoRoute():
+			if marked, ok := strings.CutPrefix(outcome, generationMarker); ok {
+				if markedGeneration, err := strconv.ParseUint(marked, 10, 64); err == nil {
+					r.generation.Store(markedGeneration)
+				}
+			} else if r.generation.Load() == generation {
+				return outcome, nil
+			} else {
+				r.logger.Warn("stale outcome discarded", "route", outcome)
+			}
+		case <-expired:
+			return "", errors.Join(g.ErrorNodeTimeout, fmt.Errorf("node [%v] produced no outcome within [%v]", r.Address(), r.timeout))
+		}
+	}
+}
+
+func (r *node) logRoutingFailure(outcome string, err error) {
+	if err != nil {
+		r.logger.Error("routing failed", "route", outcome, f.LogAttrError, err)
+	}
+}
+
 // SetResolver sets the resolver for the node
 func (r *node) SetResolver(resolver r.Resolver) {
 	r.resolver = resolver
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	ollamaAPI "github.com/ollama/ollama/api"
 
+	"github.com/morphy76/lang-actor/internal/framework"
 	f "github.com/morphy76/lang-actor/pkg/framework"
 	g "github.com/morphy76/lang-actor/pkg/graph"
 	"github.com/morphy76/lang-actor/pkg/graph/ollama"
@@ -47,8 +48,7 @@ func NewOllamaNode(
 
 	switch kind.Kind {
 	case ollama.Generate:
-		taskFn = func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
-
+		taskFn = ollamaTask(func(nodeRef g.NodeRef) error {
 			ctx, cancel := context.WithCancel(context.Background())
 
 			req := &ollamaAPI.GenerateRequest{
@@ -63,7 +63,7 @@ func NewOllamaNode(
 
 			respFunc := func(resp ollamaAPI.GenerateResponse) error {
 				if resp.Response != "" {
-					self.State().GraphState().MergeChange(ollama.Generate, resp.Response)
+					nodeRef.GraphState().MergeChange(ollama.Generate, resp.Response)
 				}
 				if resp.Done {
 					cancel()
@@ -73,15 +73,14 @@ func NewOllamaNode(
 
 			err := ollamaClient.Generate(ctx, req, respFunc)
 			if err != nil {
-				return self.State(), fmt.Errorf("error calling Ollama Generate API: %w", err)
+				return fmt.Errorf("error calling Ollama Generate API: %w", err)
 			}
 
 			<-ctx.Done()
-			self.State().ProceedOntoRoute() <- g.WhateverOutcome
-			return self.State(), nil
-		}
+			return nil
+		})
 	case ollama.Chat:
-		taskFn = func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+		taskFn = ollamaTask(func(nodeRef g.NodeRef) error {
 			messages := []ollamaAPI.Message{}
 
 			if mergedOption.System != "" {
@@ -97,7 +96,7 @@ func NewOllamaNode(
 					attrName := strings.TrimPrefix(mergedOption.UserUtterance, "{{.")
 					attrName = strings.TrimSuffix(attrName, "}}")
 					// TODO manage an array of a chat turns
-					userUtterance = self.State().GraphState().ReadAttribute(attrName).(string)
+					userUtterance = nodeRef.GraphState().ReadAttribute(attrName).(string)
 				} else {
 					userUtterance = mergedOption.UserUtterance
 				}
@@ -118,7 +117,7 @@ func NewOllamaNode(
 
 			respFunc := func(resp ollamaAPI.ChatResponse) error {
 				if resp.Message.Content != "" {
-					self.State().GraphState().MergeChange(ollama.Chat, resp.Message.Content)
+					nodeRef.GraphState().MergeChange(ollama.Chat, resp.Message.Content)
 				}
 				if resp.Done {
 					cancel()
@@ -128,13 +127,12 @@ func NewOllamaNode(
 
 			err := ollamaClient.Chat(ctx, req, respFunc)
 			if err != nil {
-				return self.State(), fmt.Errorf("error calling Ollama Chat API: %w", err)
+				return fmt.Errorf("error calling Ollama Chat API: %w", err)
 			}
 
 			<-ctx.Done()
-			self.State().ProceedOntoRoute() <- g.WhateverOutcome
-			return self.State(), nil
-		}
+			return nil
+		})
 	}
 
 	baseNode, err := newNode(forGraph, *address, taskFn)
@@ -146,3 +144,22 @@ func NewOllamaNode(
 		node: *baseNode,
 	}, nil
 }
+
+// ollamaTask calls the Ollama API off the node actor, which proceeds onto the route when the piped outcome comes back.
+func ollamaTask(call func(nodeRef g.NodeRef) error) f.ProcessingFn[g.NodeRef] {
+	return func(msg f.Message, self f.Actor[g.NodeRef]) (g.NodeRef, error) {
+		if piped, ok := msg.Payload().(f.Piped[struct{}]); ok {
+			if piped.Err != nil {
+				return self.State(), piped.Err
+			}
+			self.State().ProceedOntoRoute() <- g.WhateverOutcome
+			return self.State(), nil
+		}
+
+		nodeRef := self.State()
+		framework.PipeTo(self, func() (struct{}, error) {
+			return struct{}{}, call(nodeRef)
+		})
+		return self.State(), nil
+	}
+}
@@ -0,0 +1,246 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	m "github.com/morphy76/lang-actor/pkg/metrics"
+)
+
+var staticRegistryAssertion m.Registry = (*registry)(nil)
+
+const (
+	liveActorsName       = "lang_actor_actors_live"
+	mailboxDepthName     = "lang_actor_mailbox_depth"
+	enqueuedName         = "lang_actor_messages_enqueued_total"
+	droppedName          = "lang_actor_messages_dropped_total"
+	rejectedName         = "lang_actor_messages_rejected_total"
+	processingErrorsName = "lang_actor_processing_errors_total"
+	processingTimeName   = "lang_actor_processing_duration_seconds"
+)
+
+type policyKey struct {
+	actor  string
+	policy string
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type registry struct {
+	lock *sync.Mutex
+
+	buckets []float64
+
+	live       int64
+	depth      map[string]int
+	enqueued   map[policyKey]uint64
+	dropped    map[policyKey]uint64
+	rejected   map[policyKey]uint64
+	errors     map[string]uint64
+	processing map[string]*histogram
+}
+
+// ActorStarted records that an actor started consuming its mailbox.
+func (r *registry) ActorStarted(address url.URL) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.live++
+	r.depth[address.String()] = 0
+}
+
+// ActorStopped records that an actor stopped consuming its mailbox, dropping its series.
+func (r *registry) ActorStopped(address url.URL) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.live--
+	actor := address.String()
+	delete(r.depth, actor)
+	delete(r.errors, actor)
+	delete(r.processing, actor)
+	for _, counters := range []map[policyKey]uint64{r.enqueued, r.dropped, r.rejected} {
+		for key := range counters {
+			if key.actor == actor {
+				delete(counters, key)
+			}
+		}
+	}
+}
+
+// MessageEnqueued records a message accepted by the mailbox.
+func (r *registry) MessageEnqueued(address url.URL, policy f.BackpressurePolicy, depth int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.enqueued[policyKey{actor: address.String(), policy: policy.String()}]++
+	r.depth[address.String()] = depth
+}
+
+// MessageDropped records a message discarded by the mailbox.
+func (r *registry) MessageDropped(address url.URL, policy f.BackpressurePolicy) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.dropped[policyKey{actor: address.String(), policy: policy.String()}]++
+}
+
+// MessageRejected records a message refused by the mailbox.
+func (r *registry) MessageRejected(address url.URL, policy f.BackpressurePolicy) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.rejected[policyKey{actor: address.String(), policy: policy.String()}]++
+}
+
+// MessageProcessed records a message handled by the processing function.
+func (r *registry) MessageProcessed(address url.URL, elapsed time.Duration, depth int, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	actor := address.String()
+	if _, found := r.depth[actor]; found {
+		r.depth[actor] = depth
+	}
+	if err != nil {
+		r.errors[actor]++
+	}
+
+	h, found := r.processing[actor]
+	if !found {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.processing[actor] = h
+	}
+	seconds := elapsed.Seconds()
+	for idx, bound := range r.buckets {
+		if seconds <= bound {
+			h.counts[idx]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// WriteText writes the collected metrics using the Prometheus text exposition format.
+func (r *registry) WriteText(w io.Writer) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	writeHeader(bw, liveActorsName, "gauge", "Number of actors consuming their mailbox.")
+	fmt.Fprintf(bw, "%s %d\n", liveActorsName, r.live)
+
+	writeHeader(bw, mailboxDepthName, "gauge", "Number of messages waiting in the mailbox.")
+	for _, actor := range sortedKeys(r.depth) {
+		fmt.Fprintf(bw, "%s{actor=%s} %d\n", mailboxDepthName, quote(actor), r.depth[actor])
+	}
+
+	writePolicyCounter(bw, enqueuedName, "Messages accepted by the mailbox.", r.enqueued)
+	writePolicyCounter(bw, droppedName, "Messages discarded by the mailbox backpressure policy.", r.dropped)
+	writePolicyCounter(bw, rejectedName, "Messages refused by the mailbox backpressure policy.", r.rejected)
+
+	writeHeader(bw, processingErrorsName, "counter", "Errors returned by the processing function.")
+	for _, actor := range sortedKeys(r.errors) {
+		fmt.Fprintf(bw, "%s{actor=%s} %d\n", processingErrorsName, quote(actor), r.errors[actor])
+	}
+
+	writeHeader(bw, processingTimeName, "histogram", "Time spent in the processing function.")
+	for _, actor := range sortedKeys(r.processing) {
+		h := r.processing[actor]
+		for idx, bound := range r.buckets {
+			fmt.Fprintf(bw, "%s_bucket{actor=%s,le=%s} %d\n", processingTimeName, quote(actor), quote(formatFloat(bound)), h.counts[idx])
+		}
+		fmt.Fprintf(bw, "%s_bucket{actor=%s,le=\"+Inf\"} %d\n", processingTimeName, quote(actor), h.count)
+		fmt.Fprintf(bw, "%s_sum{actor=%s} %s\n", processingTimeName, quote(actor), formatFloat(h.sum))
+		fmt.Fprintf(bw, "%s_count{actor=%s} %d\n", processingTimeName, quote(actor), h.count)
+	}
+
+	return bw.Flush()
+}
+
+// Handler returns an HTTP handler serving the metrics using the Prometheus text exposition format.
+func (r *registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.WriteText(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// NewRegistry creates a new metrics registry using the given histogram buckets.
+func NewRegistry(buckets ...float64) m.Registry {
+	if len(buckets) == 0 {
+		buckets = m.DefaultLatencyBuckets
+	}
+	useBuckets := append([]float64(nil), buckets...)
+	sort.Float64s(useBuckets)
+
+	return &registry{
+		lock: &sync.Mutex{},
+
+		buckets: useBuckets,
+
+		depth:      make(map[string]int),
+		enqueued:   make(map[policyKey]uint64),
+		dropped:    make(map[policyKey]uint64),
+		rejected:   make(map[policyKey]uint64),
+		errors:     make(map[string]uint64),
+		processing: make(map[string]*histogram),
+	}
+}
+
+func writeHeader(w io.Writer, name string, kind string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func writePolicyCounter(w io.Writer, name string, help string, values map[policyKey]uint64) {
+	writeHeader(w, name, "counter", help)
+	keys := make([]policyKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].actor != keys[j].actor {
+			return keys[i].actor < keys[j].actor
+		}
+		return keys[i].policy < keys[j].policy
+	})
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{actor=%s,policy=%s} %d\n", name, quote(key.actor), quote(key.policy), values[key])
+	}
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	rv := make([]string, 0, len(values))
+	for key := range values {
+		rv = append(rv, key)
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,127 @@
+package metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/metrics"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+)
+
+const actorURI = "actor://example"
+
+func TestRegistryExposition(t *testing.T) {
+	t.Log("Registry exposition test suite")
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	t.Run("Expose live actors and mailbox counters", func(t *testing.T) {
+		t.Log("Should expose live actors and the per policy mailbox counters")
+
+		registry := metrics.NewRegistry()
+		registry.ActorStarted(*address)
+		registry.MessageEnqueued(*address, f.BackpressurePolicyFail, 1)
+		registry.MessageEnqueued(*address, f.BackpressurePolicyFail, 2)
+		registry.MessageRejected(*address, f.BackpressurePolicyFail)
+		registry.MessageDropped(*address, f.BackpressurePolicyDropNewest)
+
+		var sb strings.Builder
+		assert.NilError(t, registry.WriteText(&sb))
+		text := sb.String()
+
+		assert.Assert(t, strings.Contains(text, "lang_actor_actors_live 1\n"))
+		assert.Assert(t, strings.Contains(text, `lang_actor_mailbox_depth{actor="actor://example"} 2`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_messages_enqueued_total{actor="actor://example",policy="fail"} 2`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_messages_rejected_total{actor="actor://example",policy="fail"} 1`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_messages_dropped_total{actor="actor://example",policy="drop_newest"} 1`))
+	})
+
+	t.Run("Expose processing latency and errors", func(t *testing.T) {
+		t.Log("Should expose the processing latency histogram and the error counter")
+
+		registry := metrics.NewRegistry(0.1, 1)
+		registry.ActorStarted(*address)
+		registry.MessageProcessed(*address, 50*time.Millisecond, 0, nil)
+		registry.MessageProcessed(*address, 500*time.Millisecond, 0, errors.New("boom"))
+
+		var sb strings.Builder
+		assert.NilError(t, registry.WriteText(&sb))
+		text := sb.String()
+
+		assert.Assert(t, strings.Contains(text, `lang_actor_processing_duration_seconds_bucket{actor="actor://example",le="0.1"} 1`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_processing_duration_seconds_bucket{actor="actor://example",le="1"} 2`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_processing_duration_seconds_bucket{actor="actor://example",le="+Inf"} 2`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_processing_duration_seconds_count{actor="actor://example"} 2`))
+		assert.Assert(t, strings.Contains(text, `lang_actor_processing_errors_total{actor="actor://example"} 1`))
+	})
+
+	t.Run("Forget the mailbox depth of stopped actors", func(t *testing.T) {
+		t.Log("Should stop exposing the mailbox depth once the actor is stopped")
+
+		registry := metrics.NewRegistry()
+		registry.ActorStarted(*address)
+		registry.ActorStopped(*address)
+
+		var sb strings.Builder
+		assert.NilError(t, registry.WriteText(&sb))
+		text := sb.String()
+
+		assert.Assert(t, strings.Contains(text, "lang_actor_actors_live 0\n"))
+		assert.Assert(t, !strings.Contains(text, `lang_actor_mailbox_depth{`))
+	})
+
+	t.Run("Forget every series of stopped actors", func(t *testing.T) {
+		t.Log("Should stop exposing the counters and the histogram of a stopped actor, keeping the other actors")
+
+		other, err := url.Parse(actorURI + "/other")
+		assert.NilError(t, err)
+
+		registry := metrics.NewRegistry()
+		for _, actor := range []url.URL{*address, *other} {
+			registry.ActorStarted(actor)
+			registry.MessageEnqueued(actor, f.BackpressurePolicyFail, 1)
+			registry.MessageRejected(actor, f.BackpressurePolicyFail)
+			registry.MessageDropped(actor, f.BackpressurePolicyDropNewest)
+			registry.MessageProcessed(actor, time.Millisecond, 0, errors.New("boom"))
+		}
+		registry.ActorStopped(*address)
+
+		var sb strings.Builder
+		assert.NilError(t, registry.WriteText(&sb))
+		text := sb.String()
+
+		assert.Assert(t, !strings.Contains(text, `actor="actor://example"`))
+		for _, name := range []string{
+			"lang_actor_mailbox_depth",
+			"lang_actor_messages_enqueued_total",
+			"lang_actor_messages_rejected_total",
+			"lang_actor_messages_dropped_total",
+			"lang_actor_processing_errors_total",
+			"lang_actor_processing_duration_seconds_count",
+		} {
+			assert.Assert(t, strings.Contains(text, name+`{actor="actor://example/other"`), name)
+		}
+	})
+
+	t.Run("Serve the text format over HTTP", func(t *testing.T) {
+		t.Log("Should serve the metrics with the Prometheus text content type")
+
+		registry := metrics.NewRegistry()
+		registry.ActorStarted(*address)
+
+		recorder := httptest.NewRecorder()
+		registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, recorder.Code, http.StatusOK)
+		assert.Assert(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+		assert.Assert(t, strings.Contains(recorder.Body.String(), "# TYPE lang_actor_actors_live gauge"))
+	})
+}
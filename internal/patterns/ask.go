@@ -0,0 +1,25 @@
+package patterns
+
+import (
+	"time"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	p "github.com/morphy76/lang-actor/pkg/patterns"
+)
+
+// Ask sends a request to a single responder and waits for its reply.
+func Ask(payload any, responder f.ActorRef, timeout time.Duration, options ...f.ActorOption) (any, error) {
+	result, err := ScatterGather(payload, []f.ActorRef{responder}, nil, p.GatherConfig{
+		Timeout: timeout,
+		Options: options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gathered := <-result
+	if len(gathered.Responses) > 0 {
+		return gathered.Responses[0].Payload, nil
+	}
+	return nil, gathered.Failures[responder.Address()]
+}
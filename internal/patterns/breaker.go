@@ -0,0 +1,207 @@
+package patterns
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	p "github.com/morphy76/lang-actor/pkg/patterns"
+)
+
+var staticCircuitBreakerAssertion p.CircuitBreaker = (*circuitBreaker)(nil)
+
+const (
+	defaultFailureThreshold = 5
+	defaultResetTimeout     = 30 * time.Second
+	// defaultAskTimeout bounds the asks without timeout, a reply never arriving would hold a trial call forever
+	defaultAskTimeout = 5 * time.Second
+)
+
+type circuitBreaker struct {
+	lock *sync.Mutex
+
+	name          string
+	threshold     int
+	resetTimeout  time.Duration
+	halfOpenCalls int
+	isFailure     func(err error) bool
+	options       []f.ActorOption
+	clock         f.Clock
+	logger        *slog.Logger
+
+	state       p.CircuitState
+	generation  uint64
+	failures    int
+	trials      int
+	subscribers map[chan p.CircuitTransition]struct{}
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(config p.BreakerConfig) p.CircuitBreaker {
+	actorConfig := framework.NewActorConfig(config.Options...)
+	rv := &circuitBreaker{
+		lock: &sync.Mutex{},
+
+		name:          config.Name,
+		threshold:     config.FailureThreshold,
+		resetTimeout:  config.ResetTimeout,
+		halfOpenCalls: max(config.HalfOpenCalls, 1),
+		isFailure:     config.IsFailure,
+		options:       config.Options,
+		clock:         actorConfig.Clock,
+		logger:        actorConfig.Logger.With("breaker", config.Name),
+
+		state:       p.CircuitClosed,
+		subscribers: make(map[chan p.CircuitTransition]struct{}),
+	}
+	if rv.threshold <= 0 {
+		rv.threshold = defaultFailureThreshold
+	}
+	if rv.resetTimeout <= 0 {
+		rv.resetTimeout = defaultResetTimeout
+	}
+	if rv.isFailure == nil {
+		rv.isFailure = func(err error) bool { return true }
+	}
+	return rv
+}
+
+// Execute calls a function through the breaker.
+func (b *circuitBreaker) Execute(fn func() (any, error)) (rv any, err error) {
+	generation, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.release(generation, fmt.Errorf("call panicked: %v", recovered))
+			panic(recovered)
+		}
+	}()
+
+	rv, err = fn()
+	b.release(generation, err)
+	return rv, err
+}
+
+// Ask sends a request to an actor through the breaker and waits for its reply.
+func (b *circuitBreaker) Ask(payload any, responder f.ActorRef, timeout time.Duration) (any, error) {
+	if timeout <= 0 {
+		timeout = defaultAskTimeout
+	}
+	return b.Execute(func() (any, error) {
+		return Ask(payload, responder, timeout, b.options...)
+	})
+}
+
+// State returns the current state of the breaker.
+func (b *circuitBreaker) State() p.CircuitState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.state
+}
+
+// Subscribe streams the changes of state of the breaker.
+func (b *circuitBreaker) Subscribe(buffer int) (<-chan p.CircuitTransition, func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	rv := make(chan p.CircuitTransition, max(buffer, 0))
+	b.subscribers[rv] = struct{}{}
+	return rv, func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		if _, found := b.subscribers[rv]; found {
+			delete(b.subscribers, rv)
+			close(rv)
+		}
+	}
+}
+
+// acquire admits a call, returning the generation of the state admitting it.
+func (b *circuitBreaker) acquire() (uint64, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case p.CircuitOpen:
+		return 0, fmt.Errorf("breaker [%s] refused the call: %w", b.name, p.ErrorCircuitOpen)
+	case p.CircuitHalfOpen:
+		if b.trials >= b.halfOpenCalls {
+			return 0, fmt.Errorf("breaker [%s] refused the call while trying: %w", b.name, p.ErrorCircuitOpen)
+		}
+		b.trials++
+	}
+	return b.generation, nil
+}
+
+// release records the outcome of a call, ignored when the state changed since the call was admitted.
+func (b *circuitBreaker) release(generation uint64, err error) {
+	failed := err != nil && b.isFailure(err)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	switch b.state {
+	case p.CircuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.threshold {
+			b.trip()
+		}
+	case p.CircuitHalfOpen:
+		b.trials--
+		if failed {
+			b.trip()
+			return
+		}
+		b.failures = 0
+		b.transition(p.CircuitClosed)
+	}
+}
+
+// trip opens the circuit until the reset timeout elapses; lock is already held by caller.
+func (b *circuitBreaker) trip() {
+	b.transition(p.CircuitOpen)
+	b.clock.AfterFunc(b.resetTimeout, b.tryAgain)
+}
+
+func (b *circuitBreaker) tryAgain() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == p.CircuitOpen {
+		b.trials = 0
+		b.transition(p.CircuitHalfOpen)
+	}
+}
+
+// transition changes the state and notifies the subscribers; lock is already held by caller.
+func (b *circuitBreaker) transition(to p.CircuitState) {
+	event := p.CircuitTransition{Name: b.name, From: b.state, To: to, Time: b.clock.Now()}
+	b.state = to
+	b.generation++
+
+	if to == p.CircuitOpen {
+		b.logger.Warn("circuit opened", "from", event.From.String(), "failures", b.failures)
+	} else {
+		b.logger.Info("circuit state changed", "from", event.From.String(), "to", to.String())
+	}
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
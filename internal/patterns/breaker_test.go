@@ -0,0 +1,174 @@
+package patterns_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/patterns"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	p "github.com/morphy76/lang-actor/pkg/patterns"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+func expectTransition(t *testing.T, transitions <-chan p.CircuitTransition, from p.CircuitState, to p.CircuitState) {
+	t.Helper()
+
+	select {
+	case transition := <-transitions:
+		assert.Equal(t, transition.Name, "inventory")
+		assert.Equal(t, transition.From, from)
+		assert.Equal(t, transition.To, to)
+	case <-time.After(testkit.DefaultTimeout):
+		t.Fatalf("transition from %v to %v not published", from, to)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Log("Circuit breaker test suite")
+
+	errUnavailable := errors.New("unavailable")
+	failing := func() (any, error) { return nil, errUnavailable }
+	succeeding := func() (any, error) { return "stock", nil }
+
+	t.Run("Open and recover", func(t *testing.T) {
+		t.Log("Should open after the consecutive failures, refuse the calls and close after a successful trial")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		breaker := patterns.NewCircuitBreaker(p.BreakerConfig{
+			Name:             "inventory",
+			FailureThreshold: 2,
+			ResetTimeout:     10 * time.Second,
+			Options:          []f.ActorOption{b.WithClock(clock)},
+		})
+		transitions, unsubscribe := breaker.Subscribe(8)
+		defer unsubscribe()
+
+		_, err := breaker.Execute(failing)
+		assert.ErrorIs(t, err, errUnavailable)
+		_, err = breaker.Execute(succeeding)
+		assert.NilError(t, err)
+		_, err = breaker.Execute(failing)
+		assert.ErrorIs(t, err, errUnavailable)
+		assert.Equal(t, breaker.State(), p.CircuitClosed)
+
+		_, err = breaker.Execute(failing)
+		assert.ErrorIs(t, err, errUnavailable)
+		assert.Equal(t, breaker.State(), p.CircuitOpen)
+		expectTransition(t, transitions, p.CircuitClosed, p.CircuitOpen)
+
+		called := false
+		_, err = breaker.Execute(func() (any, error) {
+			called = true
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, p.ErrorCircuitOpen)
+		assert.Assert(t, !called)
+
+		clock.Advance(10 * time.Second)
+		assert.Equal(t, breaker.State(), p.CircuitHalfOpen)
+		expectTransition(t, transitions, p.CircuitOpen, p.CircuitHalfOpen)
+
+		stock, err := p.Call(breaker, func() (string, error) { return "stock", nil })
+		assert.NilError(t, err)
+		assert.Equal(t, stock, "stock")
+		assert.Equal(t, breaker.State(), p.CircuitClosed)
+		expectTransition(t, transitions, p.CircuitHalfOpen, p.CircuitClosed)
+	})
+
+	t.Run("Trip again on a failed trial", func(t *testing.T) {
+		t.Log("Should let a single trial through while half-open and open again when it fails")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		breaker := patterns.NewCircuitBreaker(p.BreakerConfig{
+			Name:             "inventory",
+			FailureThreshold: 1,
+			ResetTimeout:     time.Second,
+			IsFailure:        func(err error) bool { return errors.Is(err, errUnavailable) },
+			Options:          []f.ActorOption{b.WithClock(clock)},
+		})
+
+		_, err := breaker.Execute(func() (any, error) { return nil, errors.New("not found") })
+		assert.ErrorContains(t, err, "not found")
+		assert.Equal(t, breaker.State(), p.CircuitClosed)
+
+		_, err = breaker.Execute(failing)
+		assert.ErrorIs(t, err, errUnavailable)
+		clock.Advance(time.Second)
+		assert.Equal(t, breaker.State(), p.CircuitHalfOpen)
+
+		trialStarted := make(chan struct{})
+		trialResult := make(chan error)
+		go func() {
+			_, err := breaker.Execute(func() (any, error) {
+				close(trialStarted)
+				return nil, <-trialResult
+			})
+			trialResult <- err
+		}()
+		<-trialStarted
+
+		_, err = breaker.Execute(succeeding)
+		assert.ErrorIs(t, err, p.ErrorCircuitOpen)
+
+		trialResult <- errUnavailable
+		assert.ErrorIs(t, <-trialResult, errUnavailable)
+		assert.Equal(t, breaker.State(), p.CircuitOpen)
+		assert.Equal(t, clock.PendingTimers(), 1)
+	})
+
+	t.Run("Ask through the breaker", func(t *testing.T) {
+		t.Log("Should return the replies and count the failed and missing replies as failures")
+
+		breaker := patterns.NewCircuitBreaker(p.BreakerConfig{Name: "inventory", FailureThreshold: 2})
+
+		reply, err := breaker.Ask(21, newResponder(t, "doubler", nil, false), time.Second)
+		assert.NilError(t, err)
+		assert.Equal(t, reply, 42)
+
+		_, err = breaker.Ask(21, newResponder(t, "broken", errUnavailable, false), time.Second)
+		assert.ErrorIs(t, err, errUnavailable)
+		assert.Equal(t, breaker.State(), p.CircuitClosed)
+
+		_, err = breaker.Ask(21, newResponder(t, "silent", nil, true), 20*time.Millisecond)
+		assert.ErrorIs(t, err, p.ErrorNoReply)
+		assert.Equal(t, breaker.State(), p.CircuitOpen)
+	})
+
+	t.Run("Bound the asks without timeout", func(t *testing.T) {
+		t.Log("Should time out an ask without timeout, releasing the trial call of the half-open breaker")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		breaker := patterns.NewCircuitBreaker(p.BreakerConfig{
+			Name:             "inventory",
+			FailureThreshold: 1,
+			ResetTimeout:     time.Second,
+			Options:          []f.ActorOption{b.WithClock(clock)},
+		})
+		_, err := breaker.Execute(failing)
+		assert.ErrorIs(t, err, errUnavailable)
+		clock.Advance(time.Second)
+		assert.Equal(t, breaker.State(), p.CircuitHalfOpen)
+
+		asked := make(chan error, 1)
+		go func() {
+			_, err := breaker.Ask(21, newResponder(t, "silent", nil, true), 0)
+			asked <- err
+		}()
+		for clock.PendingTimers() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(5 * time.Second)
+		assert.ErrorIs(t, <-asked, p.ErrorNoReply)
+		assert.Equal(t, breaker.State(), p.CircuitOpen)
+
+		clock.Advance(time.Second)
+		reply, err := breaker.Execute(succeeding)
+		assert.NilError(t, err)
+		assert.Equal(t, reply, "stock")
+		assert.Equal(t, breaker.State(), p.CircuitClosed)
+	})
+}
@@ -0,0 +1,167 @@
+package patterns
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	p "github.com/morphy76/lang-actor/pkg/patterns"
+)
+
+var staticReplySlotAssertion c.Transport = (*replySlot)(nil)
+
+type gatherer struct {
+	lock *sync.Mutex
+
+	address   url.URL
+	requestID string
+	requester c.Transport
+	quorum    int
+	logger    *slog.Logger
+
+	pending  map[url.URL]struct{}
+	gathered p.Gathered
+	deadline f.Timer
+	done     bool
+	result   chan p.Gathered
+}
+
+// replySlot receives the reply of a single responder, whoever actually sends it.
+type replySlot struct {
+	gatherer  *gatherer
+	responder url.URL
+}
+
+// Address returns the address of the gatherer.
+func (s *replySlot) Address() url.URL {
+	return s.gatherer.address
+}
+
+// Deliver accepts the reply of the responder.
+func (s *replySlot) Deliver(msg any, from c.Addressable) error {
+	payload, _ := c.Unwrap(msg)
+	reply, ok := payload.(p.Reply)
+	if !ok {
+		return fmt.Errorf("gatherer of request [%s] accepts only replies, received [%T]", s.gatherer.requestID, payload)
+	}
+	s.gatherer.record(s.responder, reply.Payload, reply.Err)
+	return nil
+}
+
+// Send delivers a message to the destination on behalf of the gatherer.
+func (s *replySlot) Send(msg any, destination c.Transport) error {
+	return destination.Deliver(msg, s)
+}
+
+// ScatterGather sends a request to every responder and gathers their replies.
+//
+// The gathering completes when every responder replied, when the quorum of successful replies is reached or
+// cannot be reached anymore, or at the deadline; the result is delivered to the requester, when given, and to
+// the returned channel.
+func ScatterGather(payload any, responders []f.ActorRef, requester c.Transport, config p.GatherConfig) (<-chan p.Gathered, error) {
+	requestID := uuid.NewString()
+	address, err := url.Parse("actor://gather/" + requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gatherer address: %w", err)
+	}
+
+	actorConfig := framework.NewActorConfig(config.Options...)
+	g := &gatherer{
+		lock: &sync.Mutex{},
+
+		address:   *address,
+		requestID: requestID,
+		requester: requester,
+		quorum:    config.Quorum,
+		logger:    actorConfig.Logger.With(f.LogAttrActorAddress, address.String()),
+
+		pending: make(map[url.URL]struct{}, len(responders)),
+		gathered: p.Gathered{
+			RequestID: requestID,
+			Responses: make([]p.Response, 0, len(responders)),
+			Failures:  make(map[url.URL]error),
+		},
+		result: make(chan p.Gathered, 1),
+	}
+	if g.quorum > len(responders) {
+		g.quorum = len(responders)
+	}
+	for _, responder := range responders {
+		g.pending[responder.Address()] = struct{}{}
+	}
+
+	g.lock.Lock()
+	if config.Timeout > 0 {
+		g.deadline = actorConfig.Clock.AfterFunc(config.Timeout, g.expire)
+	}
+	g.lock.Unlock()
+
+	for _, responder := range responders {
+		slot := &replySlot{gatherer: g, responder: responder.Address()}
+		request := p.Request{ID: requestID, Payload: payload, ReplyTo: slot}
+		if err := responder.Deliver(request, slot); err != nil {
+			g.record(responder.Address(), nil, err)
+		}
+	}
+	g.complete(false)
+
+	return g.result, nil
+}
+
+func (g *gatherer) record(responder url.URL, payload any, err error) {
+	g.lock.Lock()
+	if _, pending := g.pending[responder]; !pending || g.done {
+		g.lock.Unlock()
+		return
+	}
+	delete(g.pending, responder)
+	if err != nil {
+		g.gathered.Failures[responder] = err
+	} else {
+		g.gathered.Responses = append(g.gathered.Responses, p.Response{Responder: responder, Payload: payload})
+	}
+	g.lock.Unlock()
+
+	g.complete(false)
+}
+
+func (g *gatherer) expire() {
+	g.complete(true)
+}
+
+func (g *gatherer) complete(expired bool) {
+	g.lock.Lock()
+	successes := len(g.gathered.Responses)
+	quorumSettled := g.quorum > 0 && (successes >= g.quorum || successes+len(g.pending) < g.quorum)
+	if g.done || !(expired || quorumSettled || len(g.pending) == 0) {
+		g.lock.Unlock()
+		return
+	}
+
+	g.done = true
+	if g.deadline != nil {
+		g.deadline.Stop()
+	}
+	if expired {
+		g.gathered.TimedOut = true
+		for responder := range g.pending {
+			g.gathered.Failures[responder] = errors.Join(p.ErrorNoReply, fmt.Errorf("responder [%v] did not reply", responder.String()))
+		}
+	}
+	gathered := g.gathered
+	g.lock.Unlock()
+
+	g.result <- gathered
+	if g.requester != nil {
+		if err := g.requester.Deliver(gathered, &replySlot{gatherer: g}); err != nil {
+			g.logger.Warn("gathered replies not delivered to the requester", f.LogAttrError, err)
+		}
+	}
+}
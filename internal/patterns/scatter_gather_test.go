@@ -0,0 +1,129 @@
+package patterns_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/internal/patterns"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	p "github.com/morphy76/lang-actor/pkg/patterns"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type noState struct{}
+
+// newResponder replies with the doubled request payload, fails with err or stays silent when silent.
+func newResponder(t *testing.T, name string, err error, silent bool) f.ActorRef {
+	address, parseErr := url.Parse("actor://responders/" + name)
+	assert.NilError(t, parseErr)
+
+	responder, actorErr := framework.NewActor(*address, func(msg f.Message, self f.Actor[noState]) (noState, error) {
+		request, ok := msg.Payload().(p.Request)
+		if !ok || silent {
+			return self.State(), nil
+		}
+		if err != nil {
+			return self.State(), p.Respond(request, self, nil, err)
+		}
+		return self.State(), p.Respond(request, self, request.Payload.(int)*2, nil)
+	}, noState{})
+	assert.NilError(t, actorErr)
+	t.Cleanup(func() {
+		done, _ := responder.Stop()
+		<-done
+	})
+	return responder
+}
+
+func TestScatterGather(t *testing.T) {
+	t.Log("Scatter-gather test suite")
+
+	t.Run("Gather all replies", func(t *testing.T) {
+		t.Log("Should hand every reply and every failure to the requester")
+
+		probe := testkit.NewTestProbe(t)
+		failure := errors.New("out of order")
+		first := newResponder(t, "first", nil, false)
+		second := newResponder(t, "second", nil, false)
+		broken := newResponder(t, "broken", failure, false)
+
+		result, err := patterns.ScatterGather(21, []f.ActorRef{first, second, broken}, probe, p.GatherConfig{})
+		assert.NilError(t, err)
+
+		gathered := testkit.ExpectMsgType[p.Gathered](probe)
+		assert.Assert(t, !gathered.TimedOut)
+		assert.Equal(t, len(gathered.Responses), 2)
+		for _, response := range gathered.Responses {
+			assert.Equal(t, response.Payload, 42)
+		}
+		assert.Equal(t, len(gathered.Failures), 1)
+		assert.ErrorIs(t, gathered.Failures[broken.Address()], failure)
+
+		fromChannel := <-result
+		assert.Equal(t, fromChannel.RequestID, gathered.RequestID)
+	})
+
+	t.Run("Gather the first replies", func(t *testing.T) {
+		t.Log("Should complete as soon as the quorum of successful replies is reached")
+
+		probe := testkit.NewTestProbe(t)
+		fast := newResponder(t, "fast", nil, false)
+		silent := newResponder(t, "silent", nil, true)
+
+		_, err := b.ScatterGather(1, []f.ActorRef{fast, silent}, probe, p.GatherConfig{Quorum: 1})
+		assert.NilError(t, err)
+
+		gathered := testkit.ExpectMsgType[p.Gathered](probe)
+		assert.Assert(t, !gathered.TimedOut)
+		assert.Equal(t, len(gathered.Responses), 1)
+		assert.Equal(t, gathered.Responses[0].Responder, fast.Address())
+		assert.Equal(t, len(gathered.Failures), 0)
+	})
+
+	t.Run("Give up an unreachable quorum", func(t *testing.T) {
+		t.Log("Should complete when the failures make the quorum unreachable")
+
+		broken := newResponder(t, "unreachable", errors.New("down"), false)
+		silent := newResponder(t, "waiting", nil, true)
+
+		result, err := b.ScatterGather(1, []f.ActorRef{broken, silent}, nil, p.GatherConfig{Quorum: 2})
+		assert.NilError(t, err)
+
+		select {
+		case gathered := <-result:
+			assert.Equal(t, len(gathered.Responses), 0)
+			assert.Equal(t, len(gathered.Failures), 1)
+		case <-time.After(testkit.DefaultTimeout):
+			t.Fatal("gathering not completed")
+		}
+	})
+
+	t.Run("Gather until the deadline", func(t *testing.T) {
+		t.Log("Should report the responders which did not reply before the deadline")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		replying := newResponder(t, "replying", nil, false)
+		silent := newResponder(t, "late", nil, true)
+
+		_, err := b.ScatterGather(2, []f.ActorRef{replying, silent}, probe, p.GatherConfig{
+			Timeout: time.Second,
+			Options: []f.ActorOption{b.WithClock(clock)},
+		})
+		assert.NilError(t, err)
+		probe.ExpectNoMsg(50 * time.Millisecond)
+
+		clock.Advance(time.Second)
+		gathered := testkit.ExpectMsgType[p.Gathered](probe)
+		assert.Assert(t, gathered.TimedOut)
+		assert.Equal(t, len(gathered.Responses), 1)
+		assert.Equal(t, gathered.Responses[0].Payload, 4)
+		assert.ErrorIs(t, gathered.Failures[silent.Address()], p.ErrorNoReply)
+	})
+}
@@ -0,0 +1,98 @@
+package receive
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	rc "github.com/morphy76/lang-actor/pkg/receive"
+)
+
+var staticBuilderAssertion rc.Builder[any] = (*builder[any])(nil)
+
+type logger interface {
+	Logger() *slog.Logger
+}
+
+type builder[T any] struct {
+	cases     []rc.Case[T]
+	unhandled f.ProcessingFn[T]
+	sink      rc.Sink
+}
+
+// NewBuilder creates the builder of a behavior, logging the unhandled messages unless configured otherwise.
+func NewBuilder[T any]() rc.Builder[T] {
+	return &builder[T]{sink: NewLogSink(slog.LevelWarn)}
+}
+
+// NewLogSink creates a sink logging the unhandled messages through the actor logger.
+func NewLogSink(level slog.Level) rc.Sink {
+	return func(msg f.Message, self f.ActorRef) error {
+		log := slog.Default()
+		if scoped, ok := self.(logger); ok {
+			log = scoped.Logger()
+		}
+		sender := msg.Sender()
+		log.Log(
+			context.Background(),
+			level,
+			"message unhandled",
+			f.LogAttrMessageType, fmt.Sprintf("%T", msg.Payload()),
+			"sender", sender.String(),
+		)
+		return nil
+	}
+}
+
+// NewDeadLetterSink creates a sink forwarding the unhandled messages as dead letters to a transport.
+func NewDeadLetterSink(deadLetters c.Transport) rc.Sink {
+	return func(msg f.Message, self f.ActorRef) error {
+		return self.Send(rc.DeadLetter{
+			Payload:   msg.Payload(),
+			Sender:    msg.Sender(),
+			Recipient: self.Address(),
+		}, deadLetters)
+	}
+}
+
+// On adds cases to the behavior.
+func (b *builder[T]) On(cases ...rc.Case[T]) rc.Builder[T] {
+	b.cases = append(b.cases, cases...)
+	return b
+}
+
+// OnUnhandled sets the handler of the messages matched by no case.
+func (b *builder[T]) OnUnhandled(handler f.ProcessingFn[T]) rc.Builder[T] {
+	b.unhandled = handler
+	return b
+}
+
+// WithSink sets the sink of the unhandled messages.
+func (b *builder[T]) WithSink(sink rc.Sink) rc.Builder[T] {
+	b.sink = sink
+	return b
+}
+
+// Build compiles the behavior.
+func (b *builder[T]) Build() f.ProcessingFn[T] {
+	cases := append([]rc.Case[T](nil), b.cases...)
+	unhandled := b.unhandled
+	sink := b.sink
+
+	return func(msg f.Message, self f.Actor[T]) (T, error) {
+		for _, candidate := range cases {
+			if candidate.Matches(msg) {
+				return candidate.Handle(msg, self)
+			}
+		}
+		if unhandled != nil {
+			return unhandled(msg, self)
+		}
+		if sink == nil {
+			return self.State(), nil
+		}
+		return self.State(), sink(msg, self)
+	}
+}
@@ -0,0 +1,136 @@
+package receive_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/receive"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	rc "github.com/morphy76/lang-actor/pkg/receive"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type deposit struct {
+	amount int
+}
+
+type withdraw struct {
+	amount int
+}
+
+func newAccount(probe testkit.TestProbe) rc.Builder[int] {
+	return receive.NewBuilder[int]().On(
+		rc.OnType(func(msg deposit, self f.Actor[int]) (int, error) {
+			return self.State() + msg.amount, probe.Deliver("deposited", self)
+		}),
+		rc.OnType(func(msg withdraw, self f.Actor[int]) (int, error) {
+			return self.State() - msg.amount, probe.Deliver("withdrawn", self)
+		}),
+		rc.OnMatch(func(msg f.Message) bool {
+			return msg.Payload() == "balance"
+		}, func(msg f.Message, self f.Actor[int]) (int, error) {
+			return self.State(), probe.Deliver(self.State(), self)
+		}),
+	)
+}
+
+func TestReceive(t *testing.T) {
+	t.Log("Receive behavior test suite")
+
+	address, err := url.Parse("actor://account")
+	assert.NilError(t, err)
+
+	t.Run("Dispatch by type and predicate", func(t *testing.T) {
+		t.Log("Should hand every message to the first matching case")
+
+		probe := testkit.NewTestProbe(t)
+		account, err := b.NewActor(*address, newAccount(probe).Build(), 0)
+		assert.NilError(t, err)
+		defer account.Stop()
+
+		assert.NilError(t, account.Deliver(deposit{amount: 10}, probe))
+		assert.NilError(t, account.Deliver(withdraw{amount: 3}, probe))
+		assert.NilError(t, account.Deliver("balance", probe))
+		probe.ExpectMsg("deposited")
+		probe.ExpectMsg("withdrawn")
+		probe.ExpectMsg(7)
+	})
+
+	t.Run("Log unhandled messages", func(t *testing.T) {
+		t.Log("Should log the unhandled messages and keep the state by default")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		probe := testkit.NewTestProbe(t)
+		account, err := b.NewActor(*address, newAccount(probe).Build(), 5, b.WithLogger(logger))
+		assert.NilError(t, err)
+
+		assert.NilError(t, account.Deliver(42, probe))
+		stopCompleted, err := account.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+
+		assert.Equal(t, account.State(), 5)
+		assert.Assert(t, strings.Contains(buffer.String(), "message unhandled"))
+		assert.Assert(t, strings.Contains(buffer.String(), `"message.type":"int"`))
+	})
+
+	t.Run("Forward unhandled messages", func(t *testing.T) {
+		t.Log("Should forward the unhandled messages to the dead letters or hand them to the unhandled handler")
+
+		probe := testkit.NewTestProbe(t)
+		deadLetters := testkit.NewTestProbe(t)
+		account, err := b.NewActor(*address, newAccount(probe).WithSink(b.DeadLetterSink(deadLetters)).Build(), 0)
+		assert.NilError(t, err)
+		defer account.Stop()
+
+		assert.NilError(t, account.Deliver(42, probe))
+		deadLetters.ExpectMsg(rc.DeadLetter{Payload: 42, Sender: probe.Address(), Recipient: *address})
+
+		fallback, err := b.NewActor(*address, newAccount(probe).
+			WithSink(b.DeadLetterSink(deadLetters)).
+			OnUnhandled(func(msg f.Message, self f.Actor[int]) (int, error) {
+				return -1, probe.Deliver(msg.Payload(), self)
+			}).
+			Build(), 0)
+		assert.NilError(t, err)
+		defer fallback.Stop()
+
+		assert.NilError(t, fallback.Deliver(42, probe))
+		probe.ExpectMsg(42)
+		deadLetters.ExpectNoMsg(20 * time.Millisecond)
+	})
+
+	t.Run("Sink messages without sender", func(t *testing.T) {
+		t.Log("Should log and forward the unhandled messages delivered without sender")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		probe := testkit.NewTestProbe(t)
+		logged, err := b.NewActor(*address, newAccount(probe).Build(), 0, b.WithLogger(logger))
+		assert.NilError(t, err)
+
+		assert.NilError(t, logged.Deliver(42, nil))
+		stopCompleted, err := logged.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		assert.Assert(t, strings.Contains(buffer.String(), `"sender":""`))
+
+		deadLetters := testkit.NewTestProbe(t)
+		forwarded, err := b.NewActor(*address, newAccount(probe).WithSink(b.DeadLetterSink(deadLetters)).Build(), 0)
+		assert.NilError(t, err)
+		defer forwarded.Stop()
+
+		assert.NilError(t, forwarded.Deliver(42, nil))
+		deadLetters.ExpectMsg(rc.DeadLetter{Payload: 42, Recipient: *address})
+	})
+}
@@ -0,0 +1,137 @@
+package reliable
+
+import (
+	"fmt"
+	"sync"
+
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	rl "github.com/morphy76/lang-actor/pkg/reliable"
+)
+
+// defaultWindow is the number of sequence numbers remembered per producer when no window is given.
+const defaultWindow = 1024
+
+var staticSequencedMessageAssertion f.Message = (*sequencedMessage)(nil)
+
+type sequencedMessage struct {
+	f.Message
+	payload any
+}
+
+// Payload returns the payload published by the producer.
+func (m sequencedMessage) Payload() any {
+	return m.payload
+}
+
+// dedupWindow remembers the sequence numbers processed for a producer.
+//
+// Only the sequence numbers up to the window beyond contiguous are accepted, so at most window sequence numbers
+// are remembered; the later ones are refused and left to the redeliveries of the producer. The window follows
+// the acknowledgements reported by the producer, so that a restarted consumer resumes where the producer is.
+type dedupWindow struct {
+	// contiguous is the highest sequence number below which every message was processed
+	contiguous uint64
+	seen       map[uint64]struct{}
+}
+
+func (w *dedupWindow) processed(seq uint64) bool {
+	if seq <= w.contiguous {
+		return true
+	}
+	_, found := w.seen[seq]
+	return found
+}
+
+func (w *dedupWindow) accepts(seq uint64, size int) bool {
+	return seq-w.contiguous <= uint64(size)
+}
+
+// advance skips the sequence numbers the producer reported as acknowledged.
+func (w *dedupWindow) advance(acked uint64) {
+	if acked <= w.contiguous {
+		return
+	}
+	for seq := range w.seen {
+		if seq <= acked {
+			delete(w.seen, seq)
+		}
+	}
+	w.contiguous = acked
+	w.compact()
+}
+
+func (w *dedupWindow) mark(seq uint64) {
+	w.seen[seq] = struct{}{}
+	w.compact()
+}
+
+// compact moves contiguous over the processed sequence numbers following it.
+func (w *dedupWindow) compact() {
+	for {
+		if _, found := w.seen[w.contiguous+1]; !found {
+			break
+		}
+		w.contiguous++
+		delete(w.seen, w.contiguous)
+	}
+}
+
+// NewConsumer wraps a processing function to acknowledge the Sequenced messages and discard the duplicates.
+//
+// The wrapped function receives the published payload; messages failing the processing are not acknowledged
+// and so redelivered, as the messages beyond the window of a producer. Other messages are processed unchanged.
+func NewConsumer[T any](processingFn f.ProcessingFn[T], window int) f.ProcessingFn[T] {
+	if window <= 0 {
+		window = defaultWindow
+	}
+	lock := &sync.Mutex{}
+	windows := make(map[string]*dedupWindow)
+
+	return func(msg f.Message, self f.Actor[T]) (T, error) {
+		sequenced, ok := msg.Payload().(rl.Sequenced)
+		if !ok {
+			return processingFn(msg, self)
+		}
+
+		lock.Lock()
+		producerWindow, found := windows[sequenced.ProducerID]
+		if !found {
+			producerWindow = &dedupWindow{seen: make(map[uint64]struct{})}
+			windows[sequenced.ProducerID] = producerWindow
+		}
+		producerWindow.advance(sequenced.Acked)
+		duplicate := producerWindow.processed(sequenced.Seq)
+		accepted := producerWindow.accepts(sequenced.Seq, window)
+		lock.Unlock()
+
+		if duplicate {
+			self.Logger().Debug("duplicate message discarded", "producer", sequenced.ProducerID, "seq", sequenced.Seq)
+			return self.State(), acknowledge(sequenced, self)
+		}
+		if !accepted {
+			self.Logger().Debug("message beyond the window refused", "producer", sequenced.ProducerID, "seq", sequenced.Seq)
+			return self.State(), nil
+		}
+
+		newState, err := processingFn(sequencedMessage{Message: msg, payload: sequenced.Payload}, self)
+		if err != nil {
+			return newState, err
+		}
+
+		lock.Lock()
+		producerWindow.mark(sequenced.Seq)
+		lock.Unlock()
+
+		return newState, acknowledge(sequenced, self)
+	}
+}
+
+func acknowledge(sequenced rl.Sequenced, self f.ActorRef) error {
+	if sequenced.AckTo == nil {
+		return nil
+	}
+	if err := sequenced.AckTo.Deliver(rl.Ack{ProducerID: sequenced.ProducerID, Seq: sequenced.Seq}, self); err != nil {
+		return fmt.Errorf("failed to acknowledge message [%d] of producer [%s]: %w", sequenced.Seq, sequenced.ProducerID, err)
+	}
+	return nil
+}
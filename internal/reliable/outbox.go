@@ -0,0 +1,74 @@
+package reliable
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	rl "github.com/morphy76/lang-actor/pkg/reliable"
+)
+
+var staticMemoryOutboxAssertion rl.Outbox = (*memoryOutbox)(nil)
+
+type memoryOutbox struct {
+	lock *sync.Mutex
+
+	entries map[string]map[uint64]rl.Sequenced
+	last    map[string]uint64
+}
+
+// NewMemoryOutbox creates an outbox keeping the pending messages in memory.
+func NewMemoryOutbox() rl.Outbox {
+	return &memoryOutbox{
+		lock: &sync.Mutex{},
+
+		entries: make(map[string]map[uint64]rl.Sequenced),
+		last:    make(map[string]uint64),
+	}
+}
+
+// Append stores a published message.
+func (o *memoryOutbox) Append(entry rl.Sequenced) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if _, found := o.entries[entry.ProducerID]; !found {
+		o.entries[entry.ProducerID] = make(map[uint64]rl.Sequenced)
+	}
+	entry.AckTo = nil
+	o.entries[entry.ProducerID][entry.Seq] = entry
+	o.last[entry.ProducerID] = max(o.last[entry.ProducerID], entry.Seq)
+	return nil
+}
+
+// Remove deletes an acknowledged message.
+func (o *memoryOutbox) Remove(producerID string, seq uint64) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	delete(o.entries[producerID], seq)
+	return nil
+}
+
+// Pending returns the messages not acknowledged yet, sorted by sequence number.
+func (o *memoryOutbox) Pending(producerID string) ([]rl.Sequenced, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	rv := make([]rl.Sequenced, 0, len(o.entries[producerID]))
+	for _, entry := range o.entries[producerID] {
+		rv = append(rv, entry)
+	}
+	slices.SortFunc(rv, func(a, b rl.Sequenced) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	return rv, nil
+}
+
+// LastSequence returns the highest sequence number ever appended for the producer.
+func (o *memoryOutbox) LastSequence(producerID string) (uint64, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return o.last[producerID], nil
+}
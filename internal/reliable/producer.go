@@ -0,0 +1,223 @@
+package reliable
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	rl "github.com/morphy76/lang-actor/pkg/reliable"
+)
+
+var staticProducerAssertion rl.Producer = (*producer)(nil)
+
+const (
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+)
+
+type inflight struct {
+	entry   rl.Sequenced
+	backoff time.Duration
+	timer   f.Timer
+}
+
+type producer struct {
+	lock *sync.Mutex
+
+	address        url.URL
+	consumer       c.Transport
+	outbox         rl.Outbox
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	clock          f.Clock
+	logger         *slog.Logger
+
+	seq     uint64
+	pending map[uint64]*inflight
+	closed  bool
+}
+
+// NewProducer creates a producer delivering its messages at least once to the consumer.
+//
+// The messages pending in the outbox for the producer address are redelivered immediately.
+func NewProducer(address url.URL, consumer c.Transport, config rl.ProducerConfig) (rl.Producer, error) {
+	actorConfig := framework.NewActorConfig(config.Options...)
+	rv := &producer{
+		lock: &sync.Mutex{},
+
+		address:        address,
+		consumer:       consumer,
+		outbox:         config.Outbox,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+		clock:          actorConfig.Clock,
+		logger:         actorConfig.Logger.With(f.LogAttrActorAddress, address.String()),
+
+		pending: make(map[uint64]*inflight),
+	}
+	if rv.outbox == nil {
+		rv.outbox = NewMemoryOutbox()
+	}
+	if rv.initialBackoff <= 0 {
+		rv.initialBackoff = defaultInitialBackoff
+	}
+	if rv.maxBackoff <= 0 {
+		rv.maxBackoff = defaultMaxBackoff
+	}
+
+	producerID := address.String()
+	last, err := rv.outbox.LastSequence(producerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the last sequence of producer [%s]: %w", producerID, err)
+	}
+	recovered, err := rv.outbox.Pending(producerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the pending messages of producer [%s]: %w", producerID, err)
+	}
+
+	rv.seq = last
+	for idx := range recovered {
+		recovered[idx].AckTo = rv
+		rv.seq = max(rv.seq, recovered[idx].Seq)
+		rv.track(recovered[idx])
+	}
+	for _, entry := range recovered {
+		rv.deliver(entry)
+	}
+
+	return rv, nil
+}
+
+// Address returns the producer's address.
+func (p *producer) Address() url.URL {
+	return p.address
+}
+
+// Deliver accepts the acknowledgements of the consumer.
+func (p *producer) Deliver(msg any, from c.Addressable) error {
+	payload, _ := c.Unwrap(msg)
+	ack, ok := payload.(rl.Ack)
+	if !ok {
+		return fmt.Errorf("producer [%s] accepts only acknowledgements, received [%T]", p.address.String(), payload)
+	}
+	if ack.ProducerID != p.address.String() {
+		return fmt.Errorf("acknowledgement of producer [%s] received by producer [%s]", ack.ProducerID, p.address.String())
+	}
+
+	p.lock.Lock()
+	acked, found := p.pending[ack.Seq]
+	if found {
+		acked.timer.Stop()
+		delete(p.pending, ack.Seq)
+	}
+	p.lock.Unlock()
+
+	if !found {
+		return nil
+	}
+	if err := p.outbox.Remove(ack.ProducerID, ack.Seq); err != nil {
+		return fmt.Errorf("failed to remove message [%d] from the outbox: %w", ack.Seq, err)
+	}
+	return nil
+}
+
+// Send delivers a message to the destination on behalf of the producer, without delivery guarantee.
+func (p *producer) Send(msg any, destination c.Transport) error {
+	return destination.Deliver(msg, p)
+}
+
+// Publish stores the payload in the outbox and delivers it to the consumer until acknowledged.
+func (p *producer) Publish(payload any) (uint64, error) {
+	p.lock.Lock()
+	if p.closed {
+		p.lock.Unlock()
+		return 0, rl.ErrorProducerClosed
+	}
+	entry := rl.Sequenced{
+		ProducerID: p.address.String(),
+		Seq:        p.seq + 1,
+		Payload:    payload,
+		AckTo:      p,
+	}
+	if err := p.outbox.Append(entry); err != nil {
+		p.lock.Unlock()
+		return 0, errors.Join(err, fmt.Errorf("failed to store message in the outbox of producer [%s]", entry.ProducerID))
+	}
+	p.seq = entry.Seq
+	p.track(entry)
+	p.lock.Unlock()
+
+	p.deliver(entry)
+	return entry.Seq, nil
+}
+
+// Pending returns the number of messages not acknowledged yet.
+func (p *producer) Pending() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.pending)
+}
+
+// Close stops the redeliveries, the pending messages stay in the outbox.
+func (p *producer) Close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.closed = true
+	for _, pending := range p.pending {
+		pending.timer.Stop()
+	}
+	clear(p.pending)
+}
+
+func (p *producer) track(entry rl.Sequenced) {
+	// Lock is already held by caller, or the producer is not shared yet
+	pending := &inflight{entry: entry, backoff: p.initialBackoff}
+	pending.timer = p.clock.AfterFunc(pending.backoff, func() {
+		p.redeliver(entry.Seq)
+	})
+	p.pending[entry.Seq] = pending
+}
+
+// acked returns the sequence number up to which every message was acknowledged; lock is already held by caller.
+func (p *producer) acked() uint64 {
+	rv := p.seq
+	for seq := range p.pending {
+		rv = min(rv, seq-1)
+	}
+	return rv
+}
+
+func (p *producer) redeliver(seq uint64) {
+	p.lock.Lock()
+	pending, found := p.pending[seq]
+	if !found || p.closed {
+		p.lock.Unlock()
+		return
+	}
+	pending.backoff = min(2*pending.backoff, p.maxBackoff)
+	pending.timer = p.clock.AfterFunc(pending.backoff, func() {
+		p.redeliver(seq)
+	})
+	entry := pending.entry
+	p.lock.Unlock()
+
+	p.logger.Debug("redelivering unacknowledged message", "seq", seq)
+	p.deliver(entry)
+}
+
+func (p *producer) deliver(entry rl.Sequenced) {
+	p.lock.Lock()
+	entry.Acked = p.acked()
+	p.lock.Unlock()
+
+	if err := p.consumer.Deliver(entry, p); err != nil {
+		p.logger.Debug("delivery failed, waiting for redelivery", "seq", entry.Seq, f.LogAttrError, err)
+	}
+}
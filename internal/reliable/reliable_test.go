@@ -0,0 +1,228 @@
+package reliable_test
+
+import (
+	"errors"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/internal/reliable"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	rl "github.com/morphy76/lang-actor/pkg/reliable"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type noState struct{}
+
+// lossyTransport drops the first deliveries.
+type lossyTransport struct {
+	c.Transport
+	drops *atomic.Int32
+}
+
+func (l lossyTransport) Deliver(msg any, from c.Addressable) error {
+	if l.drops.Add(-1) >= 0 {
+		return errors.New("lost")
+	}
+	return l.Transport.Deliver(msg, from)
+}
+
+// switchingTransport delivers to the current consumer, replaced when the consumer restarts.
+type switchingTransport struct {
+	c.Transport
+	current *atomic.Pointer[f.Actor[noState]]
+}
+
+func (s switchingTransport) Deliver(msg any, from c.Addressable) error {
+	return (*s.current.Load()).Deliver(msg, from)
+}
+
+func newConsumer(t *testing.T, probe testkit.TestProbe, failures int32, window int) f.Actor[noState] {
+	address, err := url.Parse("actor://consumer")
+	assert.NilError(t, err)
+
+	remaining := &atomic.Int32{}
+	remaining.Store(failures)
+	consumer, err := framework.NewActor(*address, reliable.NewConsumer(func(msg f.Message, self f.Actor[noState]) (noState, error) {
+		if remaining.Add(-1) >= 0 {
+			return self.State(), errors.New("transient failure")
+		}
+		return self.State(), self.Send(msg.Payload(), probe)
+	}, window), noState{})
+	assert.NilError(t, err)
+	return consumer
+}
+
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(testkit.DefaultTimeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReliableDelivery(t *testing.T) {
+	t.Log("Reliable delivery test suite")
+
+	address, err := url.Parse("actor://producer")
+	assert.NilError(t, err)
+
+	t.Run("Redeliver until acknowledged", func(t *testing.T) {
+		t.Log("Should redeliver with backoff the lost and the failed messages until acknowledged")
+
+		probe := testkit.NewTestProbe(t)
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		drops := &atomic.Int32{}
+		drops.Store(2)
+		consumer := lossyTransport{Transport: newConsumer(t, probe, 1, 16), drops: drops}
+
+		producer, err := reliable.NewProducer(*address, consumer, rl.ProducerConfig{
+			InitialBackoff: time.Second,
+			MaxBackoff:     4 * time.Second,
+			Options:        []f.ActorOption{b.WithClock(clock)},
+		})
+		assert.NilError(t, err)
+
+		seq, err := producer.Publish("payment")
+		assert.NilError(t, err)
+		assert.Equal(t, seq, uint64(1))
+		assert.Equal(t, producer.Pending(), 1)
+
+		clock.Advance(time.Second)
+		clock.Advance(2 * time.Second)
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		clock.Advance(4 * time.Second)
+		probe.ExpectMsg("payment")
+		eventually(t, func() bool { return producer.Pending() == 0 })
+		assert.Equal(t, clock.PendingTimers(), 0)
+	})
+
+	t.Run("Discard duplicates", func(t *testing.T) {
+		t.Log("Should process a sequence number once and acknowledge every copy")
+
+		probe := testkit.NewTestProbe(t)
+		consumer := newConsumer(t, probe, 0, 16)
+
+		first := rl.Sequenced{ProducerID: "producer", Seq: 1, Payload: "first", AckTo: probe}
+		assert.NilError(t, consumer.Deliver(first, probe))
+		assert.NilError(t, consumer.Deliver(first, probe))
+		assert.NilError(t, consumer.Deliver(rl.Sequenced{ProducerID: "producer", Seq: 2, Payload: "second", AckTo: probe}, probe))
+
+		probe.ExpectMsg("first")
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 1})
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 1})
+		probe.ExpectMsg("second")
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 2})
+	})
+
+	t.Run("Refuse beyond the window", func(t *testing.T) {
+		t.Log("Should leave the messages beyond the window to the redeliveries and process the missing one")
+
+		probe := testkit.NewTestProbe(t)
+		consumer := newConsumer(t, probe, 0, 2)
+
+		sequenced := func(seq uint64, payload string) rl.Sequenced {
+			return rl.Sequenced{ProducerID: "producer", Seq: seq, Payload: payload, AckTo: probe}
+		}
+		assert.NilError(t, consumer.Deliver(sequenced(2, "second"), probe))
+		assert.NilError(t, consumer.Deliver(sequenced(3, "third"), probe))
+		assert.NilError(t, consumer.Deliver(sequenced(4, "fourth"), probe))
+		probe.ExpectMsg("second")
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 2})
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		assert.NilError(t, consumer.Deliver(sequenced(1, "first"), probe))
+		probe.ExpectMsg("first")
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 1})
+
+		assert.NilError(t, consumer.Deliver(sequenced(3, "third"), probe))
+		assert.NilError(t, consumer.Deliver(sequenced(4, "fourth"), probe))
+		probe.ExpectMsg("third")
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 3})
+		probe.ExpectMsg("fourth")
+		probe.ExpectMsg(rl.Ack{ProducerID: "producer", Seq: 4})
+	})
+
+	t.Run("Resume after a consumer restart", func(t *testing.T) {
+		t.Log("Should accept the messages of a producer beyond the window once the consumer restarted")
+
+		probe := testkit.NewTestProbe(t)
+		current := &atomic.Pointer[f.Actor[noState]]{}
+		first := newConsumer(t, probe, 0, 4)
+		current.Store(&first)
+
+		producer, err := reliable.NewProducer(*address, switchingTransport{Transport: first, current: current}, rl.ProducerConfig{})
+		assert.NilError(t, err)
+		defer producer.Close()
+		for idx := range 10 {
+			_, err := producer.Publish(idx)
+			assert.NilError(t, err)
+			probe.ExpectMsg(idx)
+			eventually(t, func() bool { return producer.Pending() == 0 })
+		}
+
+		stopCompleted, err := first.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		restarted := newConsumer(t, probe, 0, 4)
+		current.Store(&restarted)
+
+		seq, err := producer.Publish("after restart")
+		assert.NilError(t, err)
+		assert.Equal(t, seq, uint64(11))
+		probe.ExpectMsg("after restart")
+		eventually(t, func() bool { return producer.Pending() == 0 })
+	})
+
+	t.Run("Recover from the outbox", func(t *testing.T) {
+		t.Log("Should redeliver the pending messages of a previous producer and continue its sequence")
+
+		probe := testkit.NewTestProbe(t)
+		outbox := reliable.NewMemoryOutbox()
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		drops := &atomic.Int32{}
+		drops.Store(1000)
+
+		crashed, err := reliable.NewProducer(*address, lossyTransport{Transport: probe, drops: drops}, rl.ProducerConfig{
+			Outbox:  outbox,
+			Options: []f.ActorOption{b.WithClock(clock)},
+		})
+		assert.NilError(t, err)
+		_, err = crashed.Publish("first")
+		assert.NilError(t, err)
+		_, err = crashed.Publish("second")
+		assert.NilError(t, err)
+		crashed.Close()
+		_, err = crashed.Publish("third")
+		assert.ErrorIs(t, err, rl.ErrorProducerClosed)
+
+		recovered, err := reliable.NewProducer(*address, newConsumer(t, probe, 0, 16), rl.ProducerConfig{
+			Outbox:  outbox,
+			Options: []f.ActorOption{b.WithClock(clock)},
+		})
+		assert.NilError(t, err)
+		probe.ExpectMsg("first")
+		probe.ExpectMsg("second")
+
+		seq, err := recovered.Publish("third")
+		assert.NilError(t, err)
+		assert.Equal(t, seq, uint64(3))
+		probe.ExpectMsg("third")
+		eventually(t, func() bool { return recovered.Pending() == 0 })
+
+		pending, err := outbox.Pending(address.String())
+		assert.NilError(t, err)
+		assert.Equal(t, len(pending), 0)
+	})
+}
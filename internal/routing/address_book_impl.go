@@ -4,39 +4,160 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
 	r "github.com/morphy76/lang-actor/pkg/routing"
 )
 
 var staticAddressBookAssertion r.AddressBook = (*addressBook)(nil)
 
+// registration identifies a registration, so that an expiring lease never removes its replacement.
+type registration struct {
+	addressable c.Addressable
+}
+
 type addressBook struct {
 	lock *sync.Mutex
 
-	addressables map[url.URL]c.Addressable
+	clock        f.Clock
+	addressables map[url.URL]*registration
+	watchers     map[*watcher]struct{}
 }
 
 // Register registers an actor in the addressBook.
 func (ab *addressBook) Register(addressable c.Addressable) error {
+	_, err := ab.register(addressable)
+	return err
+}
+
+func (ab *addressBook) register(addressable c.Addressable) (*registration, error) {
 	ab.lock.Lock()
 	defer ab.lock.Unlock()
 
 	if _, exists := ab.addressables[addressable.Address()]; exists {
-		return errors.Join(r.ErrorActorAlreadyRegistered, fmt.Errorf("actor [%s] already registered for scheme [%s]", addressable.Address().Host, addressable.Address().Scheme))
+		return nil, errors.Join(r.ErrorActorAlreadyRegistered, fmt.Errorf("actor [%s] already registered for scheme [%s]", addressable.Address().Host, addressable.Address().Scheme))
 	}
 
-	ab.addressables[addressable.Address()] = addressable
+	rv := &registration{addressable: addressable}
+	ab.addressables[addressable.Address()] = rv
+	ab.notify(r.Event{Kind: r.EventAdded, Address: addressable.Address(), Addressable: addressable})
+
+	return rv, nil
+}
+
+// Unregister removes an actor from the addressBook.
+func (ab *addressBook) Unregister(address url.URL) error {
+	ab.lock.Lock()
+	defer ab.lock.Unlock()
 
+	if !ab.remove(address, nil) {
+		return errors.Join(r.ErrorActorNotFound, fmt.Errorf("actor [%s] not registered", address.String()))
+	}
 	return nil
 }
 
+// remove deletes the registration of the address, only when it is the expected one if given.
+func (ab *addressBook) remove(address url.URL, expected *registration) bool {
+	current, exists := ab.addressables[address]
+	if !exists || (expected != nil && current != expected) {
+		return false
+	}
+
+	delete(ab.addressables, address)
+	ab.notify(r.Event{Kind: r.EventRemoved, Address: address, Addressable: current.addressable})
+	return true
+}
+
+// RegisterWithLease registers an actor in the addressBook until its lease expires.
+func (ab *addressBook) RegisterWithLease(addressable c.Addressable, ttl time.Duration) (r.Lease, error) {
+	registered, err := ab.register(addressable)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := &lease{
+		lock: &sync.Mutex{},
+
+		book:         ab,
+		registration: registered,
+		ttl:          ttl,
+	}
+	var timer leaseTimer
+	if ab.clock != nil {
+		timer = ab.clock.AfterFunc(ttl, rv.Revoke)
+	} else {
+		timer = time.AfterFunc(ttl, rv.Revoke)
+	}
+
+	rv.lock.Lock()
+	defer rv.lock.Unlock()
+	rv.timer = timer
+	return rv, nil
+}
+
+// Watch streams the changes of the registrations matching a pattern.
+func (ab *addressBook) Watch(pattern string) (r.Watcher, error) {
+	scheme, patternSegments, err := parsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(pattern, append([]string{scheme}, patternSegments...)); err != nil {
+		return nil, err
+	}
+
+	rv := newWatcher(ab, scheme, patternSegments)
+
+	ab.lock.Lock()
+	defer ab.lock.Unlock()
+
+	current := make([]r.Event, 0)
+	for address, registered := range ab.addressables {
+		if rv.matches(address) {
+			current = append(current, r.Event{Kind: r.EventAdded, Address: address, Addressable: registered.addressable})
+		}
+	}
+	sort.Slice(current, func(i, j int) bool {
+		return current[i].Address.String() < current[j].Address.String()
+	})
+	for _, event := range current {
+		rv.push(event)
+	}
+	ab.watchers[rv] = struct{}{}
+	return rv, nil
+}
+
+// notify pushes the event to the matching watchers, to be called with the lock held.
+func (ab *addressBook) notify(event r.Event) {
+	for w := range ab.watchers {
+		if w.matches(event.Address) {
+			w.push(event)
+		}
+	}
+}
+
+func (ab *addressBook) unwatch(w *watcher) {
+	ab.lock.Lock()
+	defer ab.lock.Unlock()
+
+	delete(ab.watchers, w)
+}
+
 // Lookup looks up an actor in the addressBook by its address.
 func (ab *addressBook) Resolve(address url.URL) (c.Addressable, bool) {
+	ab.lock.Lock()
+	defer ab.lock.Unlock()
+
 	rv, found := ab.addressables[address]
-	return rv, found
+	if !found {
+		return nil, false
+	}
+	return rv.addressable, true
 }
 
 // Query queries the addressBook for actors with a specific scheme and path.
@@ -44,7 +165,8 @@ func (ab *addressBook) Query(schema string, pathParts ...string) []c.Addressable
 	ab.lock.Lock()
 	defer ab.lock.Unlock()
 	rv := make([]c.Addressable, 0, len(ab.addressables))
-	for _, addressable := range ab.addressables {
+	for _, registered := range ab.addressables {
+		addressable := registered.addressable
 		if addressable.Address().Scheme == schema {
 			if len(pathParts) == 0 {
 				rv = append(rv, addressable)
@@ -68,21 +190,76 @@ func (ab *addressBook) Query(schema string, pathParts ...string) []c.Addressable
 	return rv
 }
 
-// TearDown tears down the addressBook and releases any resources.
-func (ab *addressBook) TearDown() {
+// Select selects the actors matching a pattern, absolute or relative to the given address.
+func (ab *addressBook) Select(pattern string, from ...url.URL) (r.Selection, error) {
+	scheme, patternSegments, err := parsePattern(pattern, from...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(pattern, append([]string{scheme}, patternSegments...)); err != nil {
+		return nil, err
+	}
+
 	ab.lock.Lock()
 	defer ab.lock.Unlock()
 
+	rv := &selection{members: make([]c.Addressable, 0)}
+	for address, registered := range ab.addressables {
+		addressScheme, segments := segmentsOf(address)
+		if schemeMatch, _ := path.Match(scheme, addressScheme); schemeMatch && match(patternSegments, segments) {
+			rv.members = append(rv.members, registered.addressable)
+		}
+	}
+	sort.Slice(rv.members, func(i, j int) bool {
+		left, right := rv.members[i].Address(), rv.members[j].Address()
+		return left.String() < right.String()
+	})
+	return rv, nil
+}
+
+// ResolveRelative resolves a path relative to an address, e.g. "../sibling".
+func (ab *addressBook) ResolveRelative(from url.URL, relative string) (c.Addressable, bool) {
+	scheme, segments, err := parsePattern(relative, from)
+	if err != nil {
+		return nil, false
+	}
+
+	address := url.URL{Scheme: scheme, Host: segments[0]}
+	if len(segments) > 1 {
+		address.Path = "/" + strings.Join(segments[1:], "/")
+	}
+	return ab.Resolve(address)
+}
+
+// TearDown tears down the addressBook and releases any resources.
+func (ab *addressBook) TearDown() {
+	ab.lock.Lock()
+	watchers := make([]*watcher, 0, len(ab.watchers))
+	for w := range ab.watchers {
+		watchers = append(watchers, w)
+	}
 	for key := range ab.addressables {
 		delete(ab.addressables, key)
 	}
+	ab.lock.Unlock()
+
+	for _, w := range watchers {
+		w.Stop()
+	}
 }
 
 // NewAddressBook creates a new addressBook instance.
-func NewAddressBook() r.AddressBook {
-	return &addressBook{
+//
+// The clock measures the leases, the wall clock when not given.
+func NewAddressBook(clock ...f.Clock) r.AddressBook {
+	rv := &addressBook{
 		lock: &sync.Mutex{},
 
-		addressables: make(map[url.URL]c.Addressable),
+		addressables: make(map[url.URL]*registration),
+		watchers:     make(map[*watcher]struct{}),
 	}
+	if len(clock) > 0 {
+		rv.clock = clock[0]
+	}
+	return rv
 }
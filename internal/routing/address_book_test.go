@@ -3,11 +3,14 @@ package routing_test
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 
 	"github.com/morphy76/lang-actor/internal/routing"
 	c "github.com/morphy76/lang-actor/pkg/common"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+	"github.com/morphy76/lang-actor/pkg/testkit"
 )
 
 var staticMockActorAssertion c.Addressable = (*mockActor)(nil)
@@ -83,3 +86,258 @@ func TestAddressBookLookup(t *testing.T) {
 		assert.Assert(t, !found)
 	})
 }
+
+var staticMockTransportAssertion c.Transport = (*mockTransport)(nil)
+
+type mockTransport struct {
+	mockActor
+	received []any
+}
+
+func (m *mockTransport) Deliver(msg any, from c.Addressable) error {
+	m.received = append(m.received, msg)
+	return nil
+}
+
+func (m *mockTransport) Send(msg any, destination c.Transport) error {
+	return destination.Deliver(msg, m)
+}
+
+func registerAll(t *testing.T, addressBook r.AddressBook, addresses ...string) {
+	for _, address := range addresses {
+		parsed, err := url.Parse(address)
+		assert.NilError(t, err)
+		assert.NilError(t, addressBook.Register(&mockTransport{mockActor: mockActor{address: *parsed}}))
+	}
+}
+
+func addressesOf(selection r.Selection) []string {
+	rv := make([]string, 0)
+	for _, member := range selection.Members() {
+		address := member.Address()
+		rv = append(rv, address.String())
+	}
+	return rv
+}
+
+func TestAddressBookSelect(t *testing.T) {
+	t.Log("AddressBook Select test suite")
+
+	addressBook := routing.NewAddressBook()
+	registerAll(t, addressBook,
+		"actor://host/workers/w1/inbox",
+		"actor://host/workers/w2/inbox",
+		"actor://host/workers/w2/outbox",
+		"actor://host/workers/w3",
+		"actor://other/workers/w1/inbox",
+	)
+
+	t.Run("Select by single segment wildcard", func(t *testing.T) {
+		t.Log("Should match exactly one segment for each star")
+
+		selection, err := addressBook.Select("actor://host/workers/*/inbox")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, addressesOf(selection), []string{
+			"actor://host/workers/w1/inbox",
+			"actor://host/workers/w2/inbox",
+		})
+	})
+
+	t.Run("Select by multi segment wildcard", func(t *testing.T) {
+		t.Log("Should match any number of segments for a double star, hosts included")
+
+		selection, err := addressBook.Select("actor://*/**/inbox")
+		assert.NilError(t, err)
+		assert.Equal(t, len(selection.Members()), 3)
+
+		selection, err = addressBook.Select("actor://host/workers/**")
+		assert.NilError(t, err)
+		assert.Equal(t, len(selection.Members()), 4)
+	})
+
+	t.Run("Broadcast to the selection", func(t *testing.T) {
+		t.Log("Should deliver the message to every selected actor")
+
+		selection, err := addressBook.Select("actor://host/workers/w2/*")
+		assert.NilError(t, err)
+		assert.NilError(t, selection.Deliver("hello", nil))
+		for _, member := range selection.Members() {
+			assert.DeepEqual(t, member.(*mockTransport).received, []any{"hello"})
+		}
+	})
+
+	t.Run("Select relative to an address", func(t *testing.T) {
+		t.Log("Should resolve the relative pattern from the given address")
+
+		from, err := url.Parse("actor://host/workers/w1")
+		assert.NilError(t, err)
+
+		selection, err := addressBook.Select("../*/inbox", *from)
+		assert.NilError(t, err)
+		assert.Equal(t, len(selection.Members()), 2)
+
+		selection, err = addressBook.Select("inbox", *from)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, addressesOf(selection), []string{"actor://host/workers/w1/inbox"})
+	})
+
+	t.Run("Reject invalid patterns", func(t *testing.T) {
+		t.Log("Should fail on malformed, hostless or unanchored relative patterns")
+
+		_, err := addressBook.Select("actor://host/[")
+		assert.ErrorIs(t, err, r.ErrorInvalidPattern)
+
+		_, err = addressBook.Select("actor:///workers")
+		assert.ErrorIs(t, err, r.ErrorInvalidPattern)
+
+		_, err = addressBook.Select("../sibling")
+		assert.ErrorIs(t, err, r.ErrorInvalidPattern)
+	})
+}
+
+func TestAddressBookResolveRelative(t *testing.T) {
+	t.Log("AddressBook ResolveRelative test suite")
+
+	addressBook := routing.NewAddressBook()
+	registerAll(t, addressBook, "actor://host/workers", "actor://host/workers/w2")
+
+	from, err := url.Parse("actor://host/workers/w1")
+	assert.NilError(t, err)
+
+	t.Run("Resolve a sibling", func(t *testing.T) {
+		t.Log("Should resolve the sibling of the given address")
+
+		sibling, found := addressBook.ResolveRelative(*from, "../w2")
+		assert.Assert(t, found)
+		address := sibling.Address()
+		assert.Equal(t, address.String(), "actor://host/workers/w2")
+	})
+
+	t.Run("Resolve the parent", func(t *testing.T) {
+		t.Log("Should resolve the parent of the given address")
+
+		parent, found := addressBook.ResolveRelative(*from, "..")
+		assert.Assert(t, found)
+		address := parent.Address()
+		assert.Equal(t, address.String(), "actor://host/workers")
+	})
+
+	t.Run("Climb above the host", func(t *testing.T) {
+		t.Log("Should not resolve a path climbing above the host")
+
+		_, found := addressBook.ResolveRelative(*from, "../../../x")
+		assert.Assert(t, !found)
+	})
+}
+
+func expectEvent(t *testing.T, watcher r.Watcher, kind r.EventKind, address string) {
+	t.Helper()
+
+	select {
+	case event := <-watcher.Events():
+		assert.Equal(t, event.Kind, kind)
+		assert.Equal(t, event.Address.String(), address)
+	case <-time.After(time.Second):
+		t.Fatalf("no %v event for [%s]", kind, address)
+	}
+}
+
+func TestAddressBookUnregister(t *testing.T) {
+	t.Log("AddressBook Unregister test suite")
+
+	t.Run("Unregister an actor", func(t *testing.T) {
+		t.Log("Should remove the registration and then fail as not found")
+
+		addressBook := routing.NewAddressBook()
+		registerAll(t, addressBook, actorURI)
+		address, err := url.Parse(actorURI)
+		assert.NilError(t, err)
+
+		assert.NilError(t, addressBook.Unregister(*address))
+		_, found := addressBook.Resolve(*address)
+		assert.Assert(t, !found)
+
+		assert.ErrorIs(t, addressBook.Unregister(*address), r.ErrorActorNotFound)
+	})
+}
+
+func TestAddressBookLease(t *testing.T) {
+	t.Log("AddressBook lease test suite")
+
+	address, err := url.Parse(actorURI)
+	assert.NilError(t, err)
+
+	t.Run("Expire a registration", func(t *testing.T) {
+		t.Log("Should keep the registration while renewed and remove it once expired")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		addressBook := routing.NewAddressBook(clock)
+		lease, err := addressBook.RegisterWithLease(&mockActor{address: *address}, time.Minute)
+		assert.NilError(t, err)
+
+		clock.Advance(50 * time.Second)
+		assert.NilError(t, lease.Renew())
+		clock.Advance(50 * time.Second)
+		_, found := addressBook.Resolve(*address)
+		assert.Assert(t, found)
+
+		clock.Advance(10 * time.Second)
+		_, found = addressBook.Resolve(*address)
+		assert.Assert(t, !found)
+		assert.ErrorIs(t, lease.Renew(), r.ErrorLeaseExpired)
+	})
+
+	t.Run("Keep the replacement", func(t *testing.T) {
+		t.Log("Should not remove a registration replacing the expired one")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		addressBook := routing.NewAddressBook(clock)
+		_, err := addressBook.RegisterWithLease(&mockActor{address: *address}, time.Minute)
+		assert.NilError(t, err)
+
+		assert.NilError(t, addressBook.Unregister(*address))
+		replacement := &mockActor{address: *address}
+		assert.NilError(t, addressBook.Register(replacement))
+
+		clock.Advance(time.Minute)
+		found, ok := addressBook.Resolve(*address)
+		assert.Assert(t, ok)
+		assert.Equal(t, found, c.Addressable(replacement))
+	})
+}
+
+func TestAddressBookWatch(t *testing.T) {
+	t.Log("AddressBook Watch test suite")
+
+	t.Run("Stream the changes", func(t *testing.T) {
+		t.Log("Should stream the current and the following changes matching the pattern")
+
+		addressBook := routing.NewAddressBook()
+		registerAll(t, addressBook, "actor://host/workers/w1")
+
+		watcher, err := addressBook.Watch("actor://host/workers/*")
+		assert.NilError(t, err)
+		defer watcher.Stop()
+		expectEvent(t, watcher, r.EventAdded, "actor://host/workers/w1")
+
+		registerAll(t, addressBook, "actor://host/other", "actor://host/workers/w2")
+		expectEvent(t, watcher, r.EventAdded, "actor://host/workers/w2")
+
+		w1, err := url.Parse("actor://host/workers/w1")
+		assert.NilError(t, err)
+		assert.NilError(t, addressBook.Unregister(*w1))
+		expectEvent(t, watcher, r.EventRemoved, "actor://host/workers/w1")
+	})
+
+	t.Run("Close the stream", func(t *testing.T) {
+		t.Log("Should close the stream when the watcher stops")
+
+		addressBook := routing.NewAddressBook()
+		watcher, err := addressBook.Watch("actor://**")
+		assert.NilError(t, err)
+
+		watcher.Stop()
+		_, open := <-watcher.Events()
+		assert.Assert(t, !open)
+	})
+}
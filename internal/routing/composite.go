@@ -0,0 +1,157 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticCompositeResolverAssertion r.Resolver = (*compositeResolver)(nil)
+
+// compositeResolver chains the resolvers of each scheme, e.g. the local address book before a name service.
+type compositeResolver struct {
+	chains map[string][]r.Resolver
+}
+
+// NewCompositeResolver creates a resolver looking up each scheme through its chain of resolvers, in order.
+//
+// Registrations go to the first resolver of the chain, lookups return the first match.
+func NewCompositeResolver(chains map[string][]r.Resolver) r.Resolver {
+	rv := &compositeResolver{chains: make(map[string][]r.Resolver, len(chains))}
+	for scheme, chain := range chains {
+		rv.chains[scheme] = append([]r.Resolver(nil), chain...)
+	}
+	return rv
+}
+
+func (cr *compositeResolver) chain(scheme string) ([]r.Resolver, error) {
+	chain, found := cr.chains[scheme]
+	if !found || len(chain) == 0 {
+		return nil, errors.Join(r.ErrorNoResolver, fmt.Errorf("no resolver for scheme [%s]", scheme))
+	}
+	return chain, nil
+}
+
+// Register registers the addressable with the first resolver of its scheme.
+func (cr *compositeResolver) Register(addressable c.Addressable) error {
+	chain, err := cr.chain(addressable.Address().Scheme)
+	if err != nil {
+		return err
+	}
+	return chain[0].Register(addressable)
+}
+
+// Unregister removes the registration from every resolver of its scheme, failing only when none had it.
+func (cr *compositeResolver) Unregister(address url.URL) error {
+	chain, err := cr.chain(address.Scheme)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	unregistered := false
+	for _, resolver := range chain {
+		if err := resolver.Unregister(address); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		unregistered = true
+	}
+	if unregistered {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// Resolve returns the first match of the resolvers of the scheme.
+func (cr *compositeResolver) Resolve(address url.URL) (c.Addressable, bool) {
+	chain, err := cr.chain(address.Scheme)
+	if err != nil {
+		return nil, false
+	}
+	for _, resolver := range chain {
+		if rv, found := resolver.Resolve(address); found {
+			return rv, true
+		}
+	}
+	return nil, false
+}
+
+// Query merges the results of the resolvers of the scheme, the first resolver winning on the same address.
+func (cr *compositeResolver) Query(schema string, pathParts ...string) []c.Addressable {
+	chain, err := cr.chain(schema)
+	if err != nil {
+		return []c.Addressable{}
+	}
+
+	results := make([][]c.Addressable, 0, len(chain))
+	for _, resolver := range chain {
+		results = append(results, resolver.Query(schema, pathParts...))
+	}
+	return merge(results...)
+}
+
+// Select merges the selections of the resolvers of the schemes matching the pattern.
+func (cr *compositeResolver) Select(pattern string, from ...url.URL) (r.Selection, error) {
+	scheme, _, err := parsePattern(pattern, from...)
+	if err != nil {
+		return nil, err
+	}
+
+	schemes := make([]string, 0, len(cr.chains))
+	for candidate := range cr.chains {
+		if matched, err := path.Match(scheme, candidate); err != nil {
+			return nil, errors.Join(r.ErrorInvalidPattern, fmt.Errorf("pattern [%s] has malformed scheme: %w", pattern, err))
+		} else if matched {
+			schemes = append(schemes, candidate)
+		}
+	}
+	sort.Strings(schemes)
+
+	results := make([][]c.Addressable, 0)
+	for _, candidate := range schemes {
+		for _, resolver := range cr.chains[candidate] {
+			selected, err := resolver.Select(pattern, from...)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, selected.Members())
+		}
+	}
+	return &selection{members: merge(results...)}, nil
+}
+
+// ResolveRelative resolves the path through the resolvers of the scheme of the starting address.
+func (cr *compositeResolver) ResolveRelative(from url.URL, relative string) (c.Addressable, bool) {
+	chain, err := cr.chain(from.Scheme)
+	if err != nil {
+		return nil, false
+	}
+	for _, resolver := range chain {
+		if rv, found := resolver.ResolveRelative(from, relative); found {
+			return rv, true
+		}
+	}
+	return nil, false
+}
+
+// merge concatenates the addressables, keeping the first one of each address.
+func merge(results ...[]c.Addressable) []c.Addressable {
+	seen := make(map[url.URL]struct{})
+	rv := make([]c.Addressable, 0)
+	for _, result := range results {
+		for _, addressable := range result {
+			if _, found := seen[addressable.Address()]; found {
+				continue
+			}
+			seen[addressable.Address()] = struct{}{}
+			rv = append(rv, addressable)
+		}
+	}
+	return rv
+}
@@ -0,0 +1,350 @@
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticFileBackendAssertion r.Backend = (*fileBackend)(nil)
+
+const (
+	defaultPollInterval = time.Second
+	lockRetryInterval   = 10 * time.Millisecond
+	lockTimeout         = 5 * time.Second
+	staleLockAge        = 30 * time.Second
+	defaultLeaseTTL     = 30 * time.Second
+)
+
+// fileRegistry is the layout of the registry file.
+type fileRegistry struct {
+	Entries []fileEntry `json:"entries" yaml:"entries"`
+}
+
+type fileEntry struct {
+	Address  string            `json:"address" yaml:"address"`
+	Endpoint string            `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	// Owner is the pid of the process holding the registration
+	Owner int `json:"owner,omitempty" yaml:"owner,omitempty"`
+	// Expires ends the lease of the registration, renewed by its owner while running; zero never expires
+	Expires time.Time `json:"expires,omitzero" yaml:"expires,omitempty"`
+}
+
+// expired tells whether the lease of the entry ended at the given time.
+func (e fileEntry) expired(now time.Time) bool {
+	return !e.Expires.IsZero() && !now.Before(e.Expires)
+}
+
+type fileBackend struct {
+	lock *sync.Mutex
+
+	path     string
+	yaml     bool
+	leaseTTL time.Duration
+	owner    int
+	owned    map[string]fileEntry
+	// nextExpiry is the earliest lease end among the loaded entries, zero when none
+	nextExpiry time.Time
+	changed    chan struct{}
+	done       chan struct{}
+	closed     bool
+}
+
+// NewFileBackend creates a backend storing the registrations in a JSON or YAML file, by extension.
+//
+// The file is shared by the processes of a host: the writers serialize through a lock file and the changes
+// are detected by polling the file every pollInterval, one second when not positive.
+//
+// Every registration is leased to the process storing it for the optional leaseTTL, thirty seconds by default: the
+// lease is renewed until the backend is closed, and the registrations left by a process which ended without closing
+// its backend are ignored once their lease expires.
+func NewFileBackend(path string, pollInterval time.Duration, leaseTTL ...time.Duration) (r.Backend, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	ttl := defaultLeaseTTL
+	if len(leaseTTL) > 0 && leaseTTL[0] > 0 {
+		ttl = leaseTTL[0]
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the registry directory: %w", err)
+	}
+
+	extension := strings.ToLower(filepath.Ext(path))
+	rv := &fileBackend{
+		lock: &sync.Mutex{},
+
+		path:     path,
+		yaml:     extension == ".yaml" || extension == ".yml",
+		leaseTTL: ttl,
+		owner:    os.Getpid(),
+		owned:    make(map[string]fileEntry),
+		changed:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	go rv.poll(pollInterval, rv.stamp())
+	return rv, nil
+}
+
+// Put stores a registration in the file, leased to this process.
+func (b *fileBackend) Put(entry r.Entry) error {
+	stored := fileEntry{
+		Address:  entry.Location.String(),
+		Endpoint: entry.Endpoint,
+		Metadata: entry.Metadata,
+		Owner:    b.owner,
+	}
+	return b.update(func(entries map[string]fileEntry, now time.Time) {
+		stored.Expires = now.Add(b.leaseTTL)
+		entries[stored.Address] = stored
+		b.owned[stored.Address] = stored
+	})
+}
+
+// Delete removes a registration from the file.
+func (b *fileBackend) Delete(address url.URL) error {
+	return b.update(func(entries map[string]fileEntry, now time.Time) {
+		delete(entries, address.String())
+		delete(b.owned, address.String())
+	})
+}
+
+// Load reads the registrations from the file, none when the file does not exist.
+func (b *fileBackend) Load() ([]r.Entry, error) {
+	registry, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nextExpiry := time.Time{}
+	rv := make([]r.Entry, 0, len(registry.Entries))
+	for _, stored := range registry.Entries {
+		if stored.expired(now) {
+			continue
+		}
+		if !stored.Expires.IsZero() && (nextExpiry.IsZero() || stored.Expires.Before(nextExpiry)) {
+			nextExpiry = stored.Expires
+		}
+		address, err := url.Parse(stored.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address [%s] in registry [%s]: %w", stored.Address, b.path, err)
+		}
+		rv = append(rv, r.Entry{Location: *address, Endpoint: stored.Endpoint, Metadata: stored.Metadata})
+	}
+
+	b.lock.Lock()
+	b.nextExpiry = nextExpiry
+	b.lock.Unlock()
+	return rv, nil
+}
+
+// Changed signals the changes of the file.
+func (b *fileBackend) Changed() <-chan struct{} {
+	return b.changed
+}
+
+// Close stops polling the file.
+func (b *fileBackend) Close() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+	return nil
+}
+
+func (b *fileBackend) read() (fileRegistry, error) {
+	rv := fileRegistry{}
+	content, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(content) == 0) {
+		return rv, nil
+	}
+	if err != nil {
+		return rv, fmt.Errorf("failed to read registry [%s]: %w", b.path, err)
+	}
+
+	if b.yaml {
+		err = yaml.Unmarshal(content, &rv)
+	} else {
+		err = json.Unmarshal(content, &rv)
+	}
+	if err != nil {
+		return rv, fmt.Errorf("failed to parse registry [%s]: %w", b.path, err)
+	}
+	return rv, nil
+}
+
+// update applies a change to the registrations while holding the lock file, then replaces the file atomically.
+//
+// The expired registrations are dropped from the file.
+func (b *fileBackend) update(change func(entries map[string]fileEntry, now time.Time)) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	registry, err := b.read()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	entries := make(map[string]fileEntry, len(registry.Entries))
+	for _, stored := range registry.Entries {
+		if !stored.expired(now) {
+			entries[stored.Address] = stored
+		}
+	}
+	change(entries, now)
+
+	registry.Entries = make([]fileEntry, 0, len(entries))
+	for _, stored := range entries {
+		registry.Entries = append(registry.Entries, stored)
+	}
+	sort.Slice(registry.Entries, func(i, j int) bool {
+		return registry.Entries[i].Address < registry.Entries[j].Address
+	})
+
+	var content []byte
+	if b.yaml {
+		content, err = yaml.Marshal(registry)
+	} else {
+		content, err = json.MarshalIndent(registry, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode registry [%s]: %w", b.path, err)
+	}
+
+	temporary, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write registry [%s]: %w", b.path, err)
+	}
+	if _, err := temporary.Write(content); err != nil {
+		temporary.Close()
+		os.Remove(temporary.Name())
+		return fmt.Errorf("failed to write registry [%s]: %w", b.path, err)
+	}
+	if err := temporary.Close(); err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("failed to write registry [%s]: %w", b.path, err)
+	}
+	if err := os.Rename(temporary.Name(), b.path); err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("failed to replace registry [%s]: %w", b.path, err)
+	}
+	return nil
+}
+
+// acquire creates the lock file shared with the other processes, breaking it when stale.
+func (b *fileBackend) acquire() (func(), error) {
+	lockPath := b.path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			lockFile.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock registry [%s]: %w", b.path, err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout locking registry [%s]", b.path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// stamp identifies the current version of the file.
+func (b *fileBackend) stamp() string {
+	info, err := os.Stat(b.path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// renew extends the leases of the registrations of this process, storing again those dropped from the file.
+func (b *fileBackend) renew() error {
+	b.lock.Lock()
+	owning := len(b.owned) > 0
+	b.lock.Unlock()
+	if !owning {
+		return nil
+	}
+
+	return b.update(func(entries map[string]fileEntry, now time.Time) {
+		for address, owned := range b.owned {
+			if stored, found := entries[address]; found && stored.Owner != b.owner {
+				delete(b.owned, address)
+				continue
+			}
+			owned.Expires = now.Add(b.leaseTTL)
+			entries[address] = owned
+			b.owned[address] = owned
+		}
+	})
+}
+
+// expiring tells whether a loaded registration reached the end of its lease.
+func (b *fileBackend) expiring(now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.nextExpiry.IsZero() || now.Before(b.nextExpiry) {
+		return false
+	}
+	b.nextExpiry = time.Time{}
+	return true
+}
+
+func (b *fileBackend) poll(interval time.Duration, last string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	renewal := time.NewTicker(b.leaseTTL / 3)
+	defer renewal.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-renewal.C:
+			// a failed renewal is retried on the next tick, the lease lasting three of them
+			b.renew()
+			continue
+		case <-ticker.C:
+		}
+
+		current := b.stamp()
+		if current == last && !b.expiring(time.Now()) {
+			continue
+		}
+		last = current
+		select {
+		case b.changed <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticLeaseAssertion r.Lease = (*lease)(nil)
+
+// leaseTimer is implemented by both the framework timers and time.Timer.
+type leaseTimer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type lease struct {
+	lock *sync.Mutex
+
+	book         *addressBook
+	registration *registration
+	ttl          time.Duration
+	timer        leaseTimer
+	expired      bool
+}
+
+// Renew extends the registration by its time to live.
+func (l *lease) Renew() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.expired {
+		address := l.registration.addressable.Address()
+		return errors.Join(r.ErrorLeaseExpired, fmt.Errorf("lease of [%s] expired", address.String()))
+	}
+	l.timer.Reset(l.ttl)
+	return nil
+}
+
+// Revoke unregisters the addressable, unless it was already replaced.
+func (l *lease) Revoke() {
+	l.lock.Lock()
+	if l.expired {
+		l.lock.Unlock()
+		return
+	}
+	l.expired = true
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.lock.Unlock()
+
+	l.book.lock.Lock()
+	defer l.book.lock.Unlock()
+	l.book.remove(l.registration.addressable.Address(), l.registration)
+}
@@ -0,0 +1,226 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
+	"sync"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticNameServiceAssertion r.NameService = (*nameService)(nil)
+
+type remoteRegistration struct {
+	entry        r.Entry
+	registration *registration
+}
+
+// nameService mirrors the registrations of the backend in an address book, so that the lookups stay local.
+type nameService struct {
+	lock *sync.Mutex
+
+	view     *addressBook
+	backend  r.Backend
+	endpoint string
+	logger   *slog.Logger
+
+	local  map[url.URL]struct{}
+	remote map[url.URL]remoteRegistration
+	done   chan struct{}
+	closed bool
+}
+
+// NewNameService creates a resolver sharing its registrations through the backend.
+//
+// The registrations of this process are stored with the given endpoint; the backend changes are followed
+// until the name service is closed. The options provide the logger.
+func NewNameService(backend r.Backend, endpoint string, options ...f.ActorOption) (r.NameService, error) {
+	config := f.ActorConfig{}
+	for _, option := range options {
+		option.Apply(&config)
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	rv := &nameService{
+		lock: &sync.Mutex{},
+
+		view:     NewAddressBook().(*addressBook),
+		backend:  backend,
+		endpoint: endpoint,
+		logger:   logger.With("component", "name-service"),
+
+		local:  make(map[url.URL]struct{}),
+		remote: make(map[url.URL]remoteRegistration),
+		done:   make(chan struct{}),
+	}
+	if err := rv.Refresh(); err != nil {
+		return nil, err
+	}
+	go rv.follow()
+	return rv, nil
+}
+
+// Register registers the addressable locally and stores its registration in the backend.
+func (ns *nameService) Register(addressable c.Addressable) error {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	registered, err := ns.view.register(addressable)
+	if err != nil {
+		return err
+	}
+	address := addressable.Address()
+	if err := ns.backend.Put(r.Entry{Location: address, Endpoint: ns.endpoint}); err != nil {
+		ns.view.lock.Lock()
+		ns.view.remove(address, registered)
+		ns.view.lock.Unlock()
+		return fmt.Errorf("failed to store registration of [%s]: %w", address.String(), err)
+	}
+	ns.local[address] = struct{}{}
+	return nil
+}
+
+// Unregister removes the registration from this process and from the backend.
+func (ns *nameService) Unregister(address url.URL) error {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	_, isLocal := ns.local[address]
+	_, isRemote := ns.remote[address]
+	if !isLocal && !isRemote {
+		return errors.Join(r.ErrorActorNotFound, fmt.Errorf("actor [%s] not registered", address.String()))
+	}
+
+	delete(ns.local, address)
+	delete(ns.remote, address)
+	ns.view.lock.Lock()
+	ns.view.remove(address, nil)
+	ns.view.lock.Unlock()
+
+	if err := ns.backend.Delete(address); err != nil {
+		return fmt.Errorf("failed to remove registration of [%s]: %w", address.String(), err)
+	}
+	return nil
+}
+
+// Resolve resolves the address to the local addressable or to the registration of another process.
+func (ns *nameService) Resolve(address url.URL) (c.Addressable, bool) {
+	return ns.view.Resolve(address)
+}
+
+// Query queries the registrations with a specific scheme and path.
+func (ns *nameService) Query(schema string, pathParts ...string) []c.Addressable {
+	return ns.view.Query(schema, pathParts...)
+}
+
+// Select selects the registrations matching a pattern.
+func (ns *nameService) Select(pattern string, from ...url.URL) (r.Selection, error) {
+	return ns.view.Select(pattern, from...)
+}
+
+// ResolveRelative resolves a path relative to an address.
+func (ns *nameService) ResolveRelative(from url.URL, path string) (c.Addressable, bool) {
+	return ns.view.ResolveRelative(from, path)
+}
+
+// Watch streams the changes of the registrations matching a pattern.
+func (ns *nameService) Watch(pattern string) (r.Watcher, error) {
+	return ns.view.Watch(pattern)
+}
+
+// Refresh mirrors the registrations of the other processes stored in the backend.
+func (ns *nameService) Refresh() error {
+	entries, err := ns.backend.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load registrations: %w", err)
+	}
+
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	stored := make(map[url.URL]struct{}, len(entries))
+	for _, entry := range entries {
+		address := entry.Address()
+		stored[address] = struct{}{}
+		if _, isLocal := ns.local[address]; isLocal {
+			continue
+		}
+
+		if known, isRemote := ns.remote[address]; isRemote {
+			if known.entry.Endpoint == entry.Endpoint && maps.Equal(known.entry.Metadata, entry.Metadata) {
+				continue
+			}
+			ns.view.lock.Lock()
+			ns.view.remove(address, known.registration)
+			ns.view.lock.Unlock()
+			delete(ns.remote, address)
+		}
+
+		registered, err := ns.view.register(entry)
+		if err != nil {
+			continue
+		}
+		ns.remote[address] = remoteRegistration{entry: entry, registration: registered}
+	}
+
+	for address, known := range ns.remote {
+		if _, found := stored[address]; found {
+			continue
+		}
+		ns.view.lock.Lock()
+		ns.view.remove(address, known.registration)
+		ns.view.lock.Unlock()
+		delete(ns.remote, address)
+	}
+	return nil
+}
+
+// Close stops following the backend and removes the registrations of this process.
+func (ns *nameService) Close() error {
+	ns.lock.Lock()
+	if ns.closed {
+		ns.lock.Unlock()
+		return nil
+	}
+	ns.closed = true
+	close(ns.done)
+	locals := make([]url.URL, 0, len(ns.local))
+	for address := range ns.local {
+		locals = append(locals, address)
+	}
+	clear(ns.local)
+	ns.lock.Unlock()
+
+	var errs []error
+	for _, address := range locals {
+		if err := ns.backend.Delete(address); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove registration of [%s]: %w", address.String(), err))
+		}
+	}
+	if err := ns.backend.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	ns.view.TearDown()
+	return errors.Join(errs...)
+}
+
+func (ns *nameService) follow() {
+	for {
+		select {
+		case <-ns.done:
+			return
+		case <-ns.backend.Changed():
+			if err := ns.Refresh(); err != nil {
+				ns.logger.Warn("name service not refreshed", f.LogAttrError, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package routing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/routing"
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+// lockedBuffer collects the log records written by the goroutine following the backend.
+type lockedBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (l *lockedBuffer) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.buffer.Write(p)
+}
+
+func (l *lockedBuffer) String() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.buffer.String()
+}
+
+func newNameService(t *testing.T, path string, endpoint string) r.NameService {
+	backend, err := routing.NewFileBackend(path, 10*time.Millisecond)
+	assert.NilError(t, err)
+	rv, err := routing.NewNameService(backend, endpoint)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		assert.NilError(t, rv.Close())
+	})
+	return rv
+}
+
+func TestNameService(t *testing.T) {
+	t.Log("Name service test suite")
+
+	address, err := url.Parse("actor://host/workers/w1")
+	assert.NilError(t, err)
+
+	for _, registry := range []string{"registry.json", "registry.yaml"} {
+		t.Run("Share registrations through "+registry, func(t *testing.T) {
+			t.Log("Should resolve the registrations of another process sharing the registry file")
+
+			path := filepath.Join(t.TempDir(), registry)
+			first := newNameService(t, path, "localhost:7001")
+			second := newNameService(t, path, "localhost:7002")
+
+			watcher, err := second.Watch("actor://host/**")
+			assert.NilError(t, err)
+			defer watcher.Stop()
+
+			actor := &mockActor{address: *address}
+			assert.NilError(t, first.Register(actor))
+
+			local, found := first.Resolve(*address)
+			assert.Assert(t, found)
+			assert.Equal(t, local, c.Addressable(actor))
+
+			expectEvent(t, watcher, r.EventAdded, address.String())
+			remote, found := second.Resolve(*address)
+			assert.Assert(t, found)
+			assert.Equal(t, remote.(r.Entry).Endpoint, "localhost:7001")
+
+			assert.NilError(t, first.Unregister(*address))
+			expectEvent(t, watcher, r.EventRemoved, address.String())
+		})
+	}
+
+	t.Run("Reject a remote address", func(t *testing.T) {
+		t.Log("Should not register an address already registered by another process")
+
+		path := filepath.Join(t.TempDir(), "registry.json")
+		first := newNameService(t, path, "localhost:7001")
+		assert.NilError(t, first.Register(&mockActor{address: *address}))
+
+		second := newNameService(t, path, "localhost:7002")
+		err := second.Register(&mockActor{address: *address})
+		assert.ErrorIs(t, err, r.ErrorActorAlreadyRegistered)
+	})
+
+	t.Run("Log through the given logger", func(t *testing.T) {
+		t.Log("Should report the failing refreshes through the logger of the options")
+
+		path := filepath.Join(t.TempDir(), "registry.json")
+		backend, err := routing.NewFileBackend(path, 10*time.Millisecond)
+		assert.NilError(t, err)
+		records := &lockedBuffer{}
+		nameService, err := routing.NewNameService(backend, "localhost:7001", f.ActorOptionFn(func(config *f.ActorConfig) {
+			config.Logger = slog.New(slog.NewJSONHandler(records, nil))
+		}))
+		assert.NilError(t, err)
+		defer nameService.Close()
+
+		assert.NilError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+		deadline := time.Now().Add(testkit.DefaultTimeout)
+		for !strings.Contains(records.String(), "name service not refreshed") {
+			if time.Now().After(deadline) {
+				t.Fatal("refresh failure not logged")
+			}
+			time.Sleep(time.Millisecond)
+		}
+		assert.Assert(t, strings.Contains(records.String(), `"component":"name-service"`))
+	})
+
+	t.Run("Ignore the expired registrations", func(t *testing.T) {
+		t.Log("Should ignore the registrations of another process once their lease expired")
+
+		path := filepath.Join(t.TempDir(), "registry.json")
+		crashed := fmt.Sprintf(`{"entries": [
+			{"address": %q, "endpoint": "localhost:7002", "owner": 1, "expires": %q},
+			{"address": "actor://host/workers/w2", "endpoint": "localhost:7002", "owner": 1, "expires": %q}
+		]}`, address.String(), time.Now().Add(-time.Second).Format(time.RFC3339Nano), time.Now().Add(50*time.Millisecond).Format(time.RFC3339Nano))
+		assert.NilError(t, os.WriteFile(path, []byte(crashed), 0o600))
+
+		nameService := newNameService(t, path, "localhost:7001")
+		watcher, err := nameService.Watch("actor://host/**")
+		assert.NilError(t, err)
+		defer watcher.Stop()
+		expectEvent(t, watcher, r.EventAdded, "actor://host/workers/w2")
+
+		_, found := nameService.Resolve(*address)
+		assert.Assert(t, !found)
+		assert.NilError(t, nameService.Register(&mockActor{address: *address}))
+		expectEvent(t, watcher, r.EventAdded, address.String())
+		expectEvent(t, watcher, r.EventRemoved, "actor://host/workers/w2")
+	})
+
+	t.Run("Renew the leases of the process", func(t *testing.T) {
+		t.Log("Should extend the leases of its registrations until closed")
+
+		path := filepath.Join(t.TempDir(), "registry.json")
+		backend, err := routing.NewFileBackend(path, 10*time.Millisecond, 30*time.Millisecond)
+		assert.NilError(t, err)
+		nameService, err := routing.NewNameService(backend, "localhost:7001")
+		assert.NilError(t, err)
+		defer nameService.Close()
+		assert.NilError(t, nameService.Register(&mockActor{address: *address}))
+
+		leased := func() time.Time {
+			content, err := os.ReadFile(path)
+			assert.NilError(t, err)
+			registry := struct {
+				Entries []struct {
+					Owner   int       `json:"owner"`
+					Expires time.Time `json:"expires"`
+				} `json:"entries"`
+			}{}
+			assert.NilError(t, json.Unmarshal(content, &registry))
+			assert.Equal(t, len(registry.Entries), 1)
+			assert.Equal(t, registry.Entries[0].Owner, os.Getpid())
+			return registry.Entries[0].Expires
+		}
+		first := leased()
+		deadline := time.Now().Add(testkit.DefaultTimeout)
+		for !leased().After(first) {
+			if time.Now().After(deadline) {
+				t.Fatal("lease not renewed")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	t.Run("Remove the registrations on close", func(t *testing.T) {
+		t.Log("Should remove the registrations of the process from the registry file when closed")
+
+		path := filepath.Join(t.TempDir(), "registry.json")
+		backend, err := routing.NewFileBackend(path, 0)
+		assert.NilError(t, err)
+		nameService, err := routing.NewNameService(backend, "localhost:7001")
+		assert.NilError(t, err)
+		assert.NilError(t, nameService.Register(&mockActor{address: *address}))
+
+		content, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(string(content), address.String()))
+
+		assert.NilError(t, nameService.Close())
+		content, err = os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Assert(t, !strings.Contains(string(content), address.String()))
+	})
+}
+
+func TestCompositeResolver(t *testing.T) {
+	t.Log("Composite resolver test suite")
+
+	address, err := url.Parse("actor://host/workers/w1")
+	assert.NilError(t, err)
+
+	t.Run("Chain the lookups", func(t *testing.T) {
+		t.Log("Should register with the first resolver and look up the chain in order")
+
+		local := routing.NewAddressBook()
+		remote := routing.NewAddressBook()
+		registerAll(t, remote, "actor://host/workers/w2")
+		resolver := routing.NewCompositeResolver(map[string][]r.Resolver{"actor": {local, remote}})
+
+		assert.NilError(t, resolver.Register(&mockActor{address: *address}))
+		_, found := local.Resolve(*address)
+		assert.Assert(t, found)
+
+		sibling, found := resolver.ResolveRelative(*address, "../w2")
+		assert.Assert(t, found)
+		siblingAddress := sibling.Address()
+		assert.Equal(t, siblingAddress.String(), "actor://host/workers/w2")
+
+		selection, err := resolver.Select("actor://host/workers/*")
+		assert.NilError(t, err)
+		assert.Equal(t, len(selection.Members()), 2)
+	})
+
+	t.Run("Unregister from every resolver", func(t *testing.T) {
+		t.Log("Should remove the registration from every resolver of the chain and fail only when none had it")
+
+		local := routing.NewAddressBook()
+		remote := routing.NewAddressBook()
+		registerAll(t, local, address.String())
+		registerAll(t, remote, address.String())
+		resolver := routing.NewCompositeResolver(map[string][]r.Resolver{"actor": {local, remote}})
+
+		assert.NilError(t, resolver.Unregister(*address))
+		_, found := local.Resolve(*address)
+		assert.Assert(t, !found)
+		_, found = remote.Resolve(*address)
+		assert.Assert(t, !found)
+
+		assert.ErrorIs(t, resolver.Unregister(*address), r.ErrorActorNotFound)
+	})
+
+	t.Run("Fail without resolvers", func(t *testing.T) {
+		t.Log("Should fail to register an address whose scheme has no resolver")
+
+		resolver := routing.NewCompositeResolver(map[string][]r.Resolver{})
+		err := resolver.Register(&mockActor{address: *address})
+		assert.ErrorIs(t, err, r.ErrorNoResolver)
+
+		_, found := resolver.Resolve(*address)
+		assert.Assert(t, !found)
+	})
+}
@@ -0,0 +1,135 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	c "github.com/morphy76/lang-actor/pkg/common"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticSelectionAssertion r.Selection = (*selection)(nil)
+
+const (
+	schemeSeparator = "://"
+	anySegments     = "**"
+)
+
+type selection struct {
+	members []c.Addressable
+}
+
+// Members returns the selected addressables.
+func (s *selection) Members() []c.Addressable {
+	return append([]c.Addressable(nil), s.members...)
+}
+
+// Deliver delivers the message to every selected addressable, joining the delivery errors.
+func (s *selection) Deliver(msg any, from c.Addressable) error {
+	return s.broadcast(func(member c.Transport) error {
+		return member.Deliver(msg, from)
+	})
+}
+
+// Send sends the message to the destination on behalf of every selected addressable, joining the errors.
+func (s *selection) Send(msg any, destination c.Transport) error {
+	return s.broadcast(func(member c.Transport) error {
+		return member.Send(msg, destination)
+	})
+}
+
+func (s *selection) broadcast(fn func(member c.Transport) error) error {
+	var errs []error
+	for _, member := range s.members {
+		address := member.Address()
+		transport, ok := member.(c.Transport)
+		if !ok {
+			errs = append(errs, fmt.Errorf("selected [%s] is not a transport", address.String()))
+			continue
+		}
+		if err := fn(transport); err != nil {
+			errs = append(errs, fmt.Errorf("delivery to [%s] failed: %w", address.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// segmentsOf splits an address into its scheme and its segments, the host being the first segment.
+func segmentsOf(address url.URL) (string, []string) {
+	return address.Scheme, append([]string{address.Host}, splitPath(address.Path)...)
+}
+
+func splitPath(p string) []string {
+	rv := make([]string, 0)
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" {
+			rv = append(rv, segment)
+		}
+	}
+	return rv
+}
+
+// parsePattern splits a pattern into its scheme and segments, resolving the relative patterns from the given addresses.
+func parsePattern(pattern string, from ...url.URL) (string, []string, error) {
+	if scheme, rest, absolute := strings.Cut(pattern, schemeSeparator); absolute {
+		segments := splitPath(rest)
+		if scheme == "" || len(segments) == 0 || strings.HasPrefix(rest, "/") {
+			return "", nil, errors.Join(r.ErrorInvalidPattern, fmt.Errorf("pattern [%s] has no scheme or host", pattern))
+		}
+		return scheme, segments, nil
+	}
+
+	if len(from) == 0 {
+		return "", nil, errors.Join(r.ErrorInvalidPattern, fmt.Errorf("relative pattern [%s] without an address to start from", pattern))
+	}
+	scheme, segments := segmentsOf(from[0])
+	if strings.HasPrefix(pattern, "/") {
+		segments = segments[:1]
+	}
+	for _, segment := range splitPath(pattern) {
+		switch segment {
+		case ".":
+		case "..":
+			if len(segments) == 1 {
+				return "", nil, errors.Join(r.ErrorInvalidPattern, fmt.Errorf("pattern [%s] climbs above [%s]", pattern, from[0].String()))
+			}
+			segments = segments[:len(segments)-1]
+		default:
+			segments = append(segments, segment)
+		}
+	}
+	return scheme, segments, nil
+}
+
+// match reports whether the segments of an address match the segments of a pattern.
+func match(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == anySegments {
+		for skip := 0; skip <= len(segments); skip++ {
+			if match(pattern[1:], segments[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], segments[0])
+	return err == nil && matched && match(pattern[1:], segments[1:])
+}
+
+// validate reports the malformed segments of a pattern.
+func validate(pattern string, segments []string) error {
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.Join(r.ErrorInvalidPattern, fmt.Errorf("pattern [%s] has malformed segment [%s]: %w", pattern, segment, err))
+		}
+	}
+	return nil
+}
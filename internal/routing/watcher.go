@@ -0,0 +1,105 @@
+package routing
+
+import (
+	"net/url"
+	"path"
+	"sync"
+
+	r "github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticWatcherAssertion r.Watcher = (*watcher)(nil)
+
+type watcher struct {
+	lock *sync.Mutex
+
+	book     *addressBook
+	scheme   string
+	segments []string
+
+	queue   []r.Event
+	signal  chan struct{}
+	events  chan r.Event
+	done    chan struct{}
+	stopped bool
+}
+
+func newWatcher(book *addressBook, scheme string, segments []string) *watcher {
+	rv := &watcher{
+		lock: &sync.Mutex{},
+
+		book:     book,
+		scheme:   scheme,
+		segments: segments,
+
+		signal: make(chan struct{}, 1),
+		events: make(chan r.Event),
+		done:   make(chan struct{}),
+	}
+	go rv.pump()
+	return rv
+}
+
+// Events returns the stream of changes.
+func (w *watcher) Events() <-chan r.Event {
+	return w.events
+}
+
+// Stop stops the watcher and closes its stream.
+func (w *watcher) Stop() {
+	w.lock.Lock()
+	if w.stopped {
+		w.lock.Unlock()
+		return
+	}
+	w.stopped = true
+	close(w.done)
+	w.lock.Unlock()
+
+	w.book.unwatch(w)
+}
+
+func (w *watcher) matches(address url.URL) bool {
+	scheme, segments := segmentsOf(address)
+	schemeMatch, _ := path.Match(w.scheme, scheme)
+	return schemeMatch && match(w.segments, segments)
+}
+
+// push queues the event without blocking, the pump delivers it to the stream.
+func (w *watcher) push(event r.Event) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.stopped {
+		return
+	}
+	w.queue = append(w.queue, event)
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) pump() {
+	defer close(w.events)
+	for {
+		w.lock.Lock()
+		pending := w.queue
+		w.queue = nil
+		w.lock.Unlock()
+
+		for _, event := range pending {
+			select {
+			case w.events <- event:
+			case <-w.done:
+				return
+			}
+		}
+
+		select {
+		case <-w.signal:
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package sharding
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	s "github.com/morphy76/lang-actor/pkg/sharding"
+)
+
+var staticCoordinatorAssertion s.Coordinator = (*coordinator)(nil)
+
+type coordinator struct {
+	lock *sync.RWMutex
+
+	shards  int
+	ring    s.HashRing
+	regions map[string]s.ShardRegion
+}
+
+// NewCoordinator creates a coordinator spreading the given number of shards over the joined regions.
+func NewCoordinator(shards int, ring s.HashRing) s.Coordinator {
+	if shards <= 0 {
+		shards = 1
+	}
+	if ring == nil {
+		ring = NewHashRing(defaultVirtualNodes)
+	}
+	return &coordinator{
+		lock: &sync.RWMutex{},
+
+		shards:  shards,
+		ring:    ring,
+		regions: make(map[string]s.ShardRegion),
+	}
+}
+
+// ShardOf returns the shard of the entity.
+func (c *coordinator) ShardOf(entityID string) int {
+	return int(hash(entityID) % uint32(c.shards))
+}
+
+// WithOwner calls fn with the address of the region owning the shard, no rebalance happens until fn returns.
+func (c *coordinator) WithOwner(shard int, fn func(owner url.URL) error) error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	member, found := c.ring.Locate(strconv.Itoa(shard))
+	if !found {
+		return errors.Join(s.ErrorNoShardRegion, fmt.Errorf("no region for shard [%d]", shard))
+	}
+	return fn(c.regions[member].Address())
+}
+
+// Join adds a region, rebalancing the shards.
+func (c *coordinator) Join(region s.ShardRegion) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	address := region.Address()
+	member := address.String()
+	if _, found := c.regions[member]; found {
+		return fmt.Errorf("region [%s] already joined", member)
+	}
+
+	before := c.allocation()
+	c.ring.Add(member)
+	c.release(before, c.allocation())
+	c.regions[member] = region
+
+	return nil
+}
+
+// Leave removes a region, rebalancing its shards onto the remaining regions.
+func (c *coordinator) Leave(region s.ShardRegion) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	address := region.Address()
+	member := address.String()
+	if _, found := c.regions[member]; !found {
+		return
+	}
+
+	before := c.allocation()
+	c.ring.Remove(member)
+	c.release(before, c.allocation())
+	delete(c.regions, member)
+}
+
+// Regions returns the addresses of the joined regions.
+func (c *coordinator) Regions() []url.URL {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	rv := make([]url.URL, 0, len(c.regions))
+	for _, member := range c.ring.Members() {
+		rv = append(rv, c.regions[member].Address())
+	}
+	return rv
+}
+
+func (c *coordinator) allocation() []string {
+	rv := make([]string, c.shards)
+	for shard := range c.shards {
+		rv[shard], _ = c.ring.Locate(strconv.Itoa(shard))
+	}
+	return rv
+}
+
+func (c *coordinator) release(before []string, after []string) {
+	// Lock is already held by caller (Join and Leave methods)
+	moving := make(map[string][]int)
+	for shard, owner := range before {
+		if owner != "" && owner != after[shard] {
+			moving[owner] = append(moving[owner], shard)
+		}
+	}
+	for owner, shards := range moving {
+		if region, found := c.regions[owner]; found {
+			region.Release(shards...)
+		}
+	}
+}
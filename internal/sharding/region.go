@@ -0,0 +1,254 @@
+package sharding
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	c "github.com/morphy76/lang-actor/pkg/common"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	r "github.com/morphy76/lang-actor/pkg/routing"
+	s "github.com/morphy76/lang-actor/pkg/sharding"
+)
+
+var staticShardRegionAssertion s.ShardRegion = (*region[any])(nil)
+
+type regionState struct{}
+
+type hostedEntity struct {
+	shard int
+	ref   f.ActorRef
+}
+
+type senderAddress url.URL
+
+func (a senderAddress) Address() url.URL {
+	return url.URL(a)
+}
+
+type region[T any] struct {
+	f.Actor[regionState]
+
+	lock *sync.Mutex
+
+	typeName    string
+	coordinator s.Coordinator
+	addressBook r.AddressBook
+	resolver    r.Resolver
+	// shared is set when the resolver is not the system address book, the region registers in both
+	shared      bool
+	factory     f.ActorFactory[T]
+	passivation f.PassivationConfig[T]
+	options     []f.ActorOption
+	entities    map[string]hostedEntity
+}
+
+// NewShardRegion creates the shard region of the given entity type in the actor system and joins the coordinator.
+//
+// Until stopped, the region is registered in the system address book at actor://<system>/sharding/<typeName>, and in
+// the resolver of the s.RegionResolver option when given; the other regions are resolved through that resolver, the
+// system address book by default. The entities live at actor://sharding/<typeName>/<entityID> whatever region hosts
+// them, so that a shared state store follows them.
+func NewShardRegion[T any](
+	system f.ActorSystem,
+	typeName string,
+	coordinator s.Coordinator,
+	factory f.ActorFactory[T],
+	passivation f.PassivationConfig[T],
+	options ...f.ActorOption,
+) (s.ShardRegion, error) {
+	address, err := url.Parse(fmt.Sprintf("actor://%s/sharding/%s", system.Name(), url.PathEscape(typeName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse region address: %w", err)
+	}
+
+	settings := s.Settings{}
+	for _, option := range options {
+		if regionOption, ok := option.(s.RegionOption); ok {
+			regionOption.ApplyRegion(&settings)
+		}
+	}
+	resolver := settings.Resolver
+	if resolver == nil {
+		resolver = system.AddressBook()
+	}
+
+	withSystem := f.ActorOptionFn(func(config *f.ActorConfig) {
+		config.System = system
+	})
+	rv := &region[T]{
+		lock: &sync.Mutex{},
+
+		typeName:    typeName,
+		coordinator: coordinator,
+		addressBook: system.AddressBook(),
+		resolver:    resolver,
+		shared:      resolver != system.AddressBook(),
+		factory:     factory,
+		passivation: passivation,
+		options:     append([]f.ActorOption{withSystem}, options...),
+		entities:    make(map[string]hostedEntity),
+	}
+
+	regionOptions := append([]f.ActorOption{withSystem}, options...)
+	regionOptions = append(regionOptions, f.MailboxConfig{Policy: f.BackpressurePolicyUnbounded})
+	actor, err := framework.NewActor(*address, rv.route, regionState{}, regionOptions...)
+	if err != nil {
+		return nil, err
+	}
+	rv.Actor = actor
+
+	if err := rv.addressBook.Register(rv); err != nil {
+		rv.Actor.Stop()
+		return nil, fmt.Errorf("failed to register region [%v]: %w", address.String(), err)
+	}
+	if rv.shared {
+		if err := resolver.Register(rv); err != nil {
+			rv.unregister()
+			rv.Actor.Stop()
+			return nil, fmt.Errorf("failed to register region [%v] in the resolver: %w", address.String(), err)
+		}
+	}
+	if err := coordinator.Join(rv); err != nil {
+		rv.unregister()
+		rv.Actor.Stop()
+		return nil, fmt.Errorf("failed to join region [%v]: %w", address.String(), err)
+	}
+
+	return rv, nil
+}
+
+// TypeName returns the type of the entities hosted by the region.
+func (r *region[T]) TypeName() string {
+	return r.typeName
+}
+
+// Entities returns the identities of the entities hosted by the region.
+func (r *region[T]) Entities() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rv := make([]string, 0, len(r.entities))
+	for entityID := range r.entities {
+		rv = append(rv, entityID)
+	}
+	slices.Sort(rv)
+	return rv
+}
+
+// Release stops the entities of the given shards, storing their state.
+func (r *region[T]) Release(shards ...int) {
+	r.lock.Lock()
+	released := make([]f.ActorRef, 0)
+	for entityID, entity := range r.entities {
+		if slices.Contains(shards, entity.shard) {
+			released = append(released, entity.ref)
+			delete(r.entities, entityID)
+		}
+	}
+	r.lock.Unlock()
+
+	stopEntities(released)
+}
+
+// Stop leaves the coordinator, handing the shards over to the remaining regions, and stops the region.
+func (r *region[T]) Stop() (chan bool, error) {
+	r.coordinator.Leave(r)
+
+	r.lock.Lock()
+	remaining := make([]f.ActorRef, 0, len(r.entities))
+	for _, entity := range r.entities {
+		remaining = append(remaining, entity.ref)
+	}
+	clear(r.entities)
+	r.lock.Unlock()
+
+	stopEntities(remaining)
+	r.unregister()
+	return r.Actor.Stop()
+}
+
+func (r *region[T]) route(msg f.Message, self f.Actor[regionState]) (regionState, error) {
+	envelope, ok := msg.Payload().(s.ShardEnvelope)
+	if !ok {
+		return self.State(), errors.Join(s.ErrorUnsupportedMessage, fmt.Errorf("region [%s] received [%T]", r.typeName, msg.Payload()))
+	}
+
+	from := senderAddress(msg.Sender())
+	shard := r.coordinator.ShardOf(envelope.EntityID)
+	err := r.coordinator.WithOwner(shard, func(owner url.URL) error {
+		if owner != r.Address() {
+			return r.forward(owner, c.Envelope{Payload: envelope, Metadata: msg.Metadata()}, from)
+		}
+
+		entity, err := r.entity(shard, envelope.EntityID)
+		if err != nil {
+			return err
+		}
+		return entity.Deliver(c.Envelope{Payload: envelope.Payload, Metadata: msg.Metadata()}, from)
+	})
+
+	return self.State(), err
+}
+
+// forward delivers the envelope to the owning region through the transport its address resolves to.
+func (r *region[T]) forward(owner url.URL, envelope c.Envelope, from c.Addressable) error {
+	addressable, found := r.resolver.Resolve(owner)
+	if !found {
+		return errors.Join(s.ErrorRegionUnreachable, fmt.Errorf("region [%v] not resolved", owner.String()))
+	}
+	transport, ok := addressable.(c.Transport)
+	if !ok {
+		return errors.Join(s.ErrorRegionUnreachable, fmt.Errorf("region [%v] resolved to [%T], not a transport", owner.String(), addressable))
+	}
+	return transport.Deliver(envelope, from)
+}
+
+// unregister removes the region from the system address book and from the configured resolver.
+func (r *region[T]) unregister() {
+	r.unregisterFrom(r.addressBook)
+	if r.shared {
+		r.unregisterFrom(r.resolver)
+	}
+}
+
+func (r *region[T]) unregisterFrom(resolver r.Resolver) {
+	address := r.Address()
+	if registered, found := resolver.Resolve(address); found && registered == c.Addressable(r) {
+		if err := resolver.Unregister(address); err != nil {
+			r.Logger().Debug("region not unregistered", f.LogAttrError, err)
+		}
+	}
+}
+
+func (r *region[T]) entity(shard int, entityID string) (f.ActorRef, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if entity, found := r.entities[entityID]; found && entity.ref.Status() == f.ActorStatusRunning {
+		return entity.ref, nil
+	}
+
+	address, err := url.Parse(fmt.Sprintf("actor://sharding/%s/%s", url.PathEscape(r.typeName), url.PathEscape(entityID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse entity address: %w", err)
+	}
+	ref, err := framework.NewPassivatingActor(*address, r.factory, r.passivation, r.options...)
+	if err != nil {
+		return nil, err
+	}
+	r.entities[entityID] = hostedEntity{shard: shard, ref: ref}
+
+	return ref, nil
+}
+
+func stopEntities(entities []f.ActorRef) {
+	for _, entity := range entities {
+		if stopCompleted, err := entity.Stop(); err == nil {
+			<-stopCompleted
+		}
+	}
+}
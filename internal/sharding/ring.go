@@ -0,0 +1,113 @@
+package sharding
+
+import (
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+
+	s "github.com/morphy76/lang-actor/pkg/sharding"
+)
+
+var staticHashRingAssertion s.HashRing = (*hashRing)(nil)
+
+// defaultVirtualNodes is the number of points of each member on the ring when none is given.
+const defaultVirtualNodes = 100
+
+type ringPoint struct {
+	hash   uint32
+	member string
+}
+
+type hashRing struct {
+	lock *sync.Mutex
+
+	virtualNodes int
+	points       []ringPoint
+	members      map[string]struct{}
+}
+
+// NewHashRing creates a consistent hashing ring placing each member on the given number of points.
+func NewHashRing(virtualNodes int) s.HashRing {
+	if virtualNodes <= 0 {
+		virtualNodes = defaultVirtualNodes
+	}
+	return &hashRing{
+		lock: &sync.Mutex{},
+
+		virtualNodes: virtualNodes,
+		members:      make(map[string]struct{}),
+	}
+}
+
+// Add adds a member to the ring.
+func (r *hashRing) Add(member string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, found := r.members[member]; found {
+		return
+	}
+	r.members[member] = struct{}{}
+	for i := range r.virtualNodes {
+		r.points = append(r.points, ringPoint{hash: hash(member + "#" + strconv.Itoa(i)), member: member})
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].member < r.points[j].member
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+}
+
+// Remove removes a member from the ring.
+func (r *hashRing) Remove(member string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, found := r.members[member]; !found {
+		return
+	}
+	delete(r.members, member)
+	r.points = slices.DeleteFunc(r.points, func(point ringPoint) bool {
+		return point.member == member
+	})
+}
+
+// Locate returns the member owning the key.
+func (r *hashRing) Locate(key string) (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.points) == 0 {
+		return "", false
+	}
+	keyHash := hash(key)
+	idx := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= keyHash
+	})
+	if idx == len(r.points) {
+		idx = 0
+	}
+	return r.points[idx].member, true
+}
+
+// Members returns the members of the ring.
+func (r *hashRing) Members() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rv := make([]string, 0, len(r.members))
+	for member := range r.members {
+		rv = append(rv, member)
+	}
+	slices.Sort(rv)
+	return rv
+}
+
+func hash(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
@@ -0,0 +1,215 @@
+package sharding_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/internal/routing"
+	"github.com/morphy76/lang-actor/internal/sharding"
+	b "github.com/morphy76/lang-actor/pkg/builders"
+	f "github.com/morphy76/lang-actor/pkg/framework"
+	s "github.com/morphy76/lang-actor/pkg/sharding"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type counted struct {
+	EntityID string
+	Count    int
+}
+
+func newCounterFactory(probe testkit.TestProbe) f.ActorFactory[int] {
+	return func(address url.URL) (f.ProcessingFn[int], int, error) {
+		entityID := path.Base(address.Path)
+		return func(msg f.Message, self f.Actor[int]) (int, error) {
+			count := self.State() + 1
+			return count, self.Send(counted{EntityID: entityID, Count: count}, probe)
+		}, 0, nil
+	}
+}
+
+func expectCounts(t *testing.T, probe testkit.TestProbe, expected map[string]int) {
+	t.Helper()
+
+	received := make(map[string]int, len(expected))
+	for range expected {
+		msg := testkit.ExpectMsgType[counted](probe)
+		received[msg.EntityID] = msg.Count
+	}
+	assert.DeepEqual(t, received, expected)
+}
+
+func TestHashRing(t *testing.T) {
+	t.Log("HashRing test suite")
+
+	t.Run("Move keys only to the new member", func(t *testing.T) {
+		t.Log("Should relocate only the keys taken over by a joining member")
+
+		ring := sharding.NewHashRing(50)
+		_, found := ring.Locate("key")
+		assert.Assert(t, !found)
+
+		ring.Add("a")
+		ring.Add("b")
+		before := make(map[string]string)
+		for i := range 1000 {
+			key := fmt.Sprintf("key-%d", i)
+			before[key], _ = ring.Locate(key)
+		}
+
+		ring.Add("c")
+		moved := 0
+		for key, owner := range before {
+			after, _ := ring.Locate(key)
+			if after != owner {
+				assert.Equal(t, after, "c")
+				moved++
+			}
+		}
+		assert.Assert(t, moved > 0 && moved < len(before))
+		assert.DeepEqual(t, ring.Members(), []string{"a", "b", "c"})
+
+		ring.Remove("c")
+		for key, owner := range before {
+			after, _ := ring.Locate(key)
+			assert.Equal(t, after, owner)
+		}
+	})
+}
+
+func TestSharding(t *testing.T) {
+	t.Log("Sharding test suite")
+
+	t.Run("Rebalance entities across systems", func(t *testing.T) {
+		t.Log("Should route the entities through any region and keep their state when regions join or leave")
+
+		probe := testkit.NewTestProbe(t)
+		store := framework.NewMemoryStateStore[int]()
+		coordinator := sharding.NewCoordinator(32, nil)
+		passivation := f.PassivationConfig[int]{Store: store}
+		resolver := routing.NewAddressBook()
+
+		newRegion := func(name string) s.ShardRegion {
+			system := framework.NewActorSystem(name)
+			region, err := sharding.NewShardRegion(system, "counter", coordinator, newCounterFactory(probe), passivation, b.WithRegionResolver(resolver))
+			assert.NilError(t, err)
+			regionAddress := region.Address()
+			_, found := system.AddressBook().Resolve(regionAddress)
+			assert.Assert(t, found)
+			_, found = resolver.Resolve(regionAddress)
+			assert.Assert(t, found)
+			return region
+		}
+		first, second := newRegion("first"), newRegion("second")
+		assert.Equal(t, len(coordinator.Regions()), 2)
+
+		entities := make([]string, 0, 20)
+		expected := make(map[string]int)
+		deliverAll := func(through s.ShardRegion) {
+			for _, entityID := range entities {
+				assert.NilError(t, through.Deliver(s.ShardEnvelope{EntityID: entityID, Payload: "inc"}, probe))
+				expected[entityID]++
+			}
+			expectCounts(t, probe, expected)
+		}
+		for i := range 20 {
+			entities = append(entities, fmt.Sprintf("entity-%d", i))
+		}
+
+		deliverAll(first)
+		assert.Equal(t, len(first.Entities())+len(second.Entities()), len(entities))
+
+		third := newRegion("third")
+		deliverAll(second)
+		assert.Assert(t, len(third.Entities()) > 0)
+		assert.Equal(t, len(first.Entities())+len(second.Entities())+len(third.Entities()), len(entities))
+
+		stopCompleted, err := first.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		assert.Equal(t, len(coordinator.Regions()), 2)
+		assert.Equal(t, len(first.Entities()), 0)
+		_, found := resolver.Resolve(first.Address())
+		assert.Assert(t, !found)
+
+		deliverAll(third)
+		assert.Equal(t, len(second.Entities())+len(third.Entities()), len(entities))
+	})
+
+	t.Run("Create a region again after a stop", func(t *testing.T) {
+		t.Log("Should unregister a stopped region from the system address book, so that it can be created again")
+
+		probe := testkit.NewTestProbe(t)
+		coordinator := sharding.NewCoordinator(8, nil)
+		passivation := f.PassivationConfig[int]{Store: framework.NewMemoryStateStore[int]()}
+		system := framework.NewActorSystem("again")
+
+		region, err := sharding.NewShardRegion(system, "counter", coordinator, newCounterFactory(probe), passivation)
+		assert.NilError(t, err)
+		stopCompleted, err := region.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		_, found := system.AddressBook().Resolve(region.Address())
+		assert.Assert(t, !found)
+
+		again, err := sharding.NewShardRegion(system, "counter", coordinator, newCounterFactory(probe), passivation)
+		assert.NilError(t, err)
+		defer again.Stop()
+		assert.NilError(t, again.Deliver(s.ShardEnvelope{EntityID: "entity", Payload: "inc"}, probe))
+		probe.ExpectMsg(counted{EntityID: "entity", Count: 1})
+	})
+
+	t.Run("Fail without regions", func(t *testing.T) {
+		t.Log("Should fail the routing without regions")
+
+		coordinator := sharding.NewCoordinator(8, nil)
+		err := coordinator.WithOwner(coordinator.ShardOf("entity"), func(owner url.URL) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, s.ErrorNoShardRegion)
+	})
+
+	t.Run("Fail the unreachable owners", func(t *testing.T) {
+		t.Log("Should not forward to a region resolving to no transport")
+
+		var buffer bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		probe := testkit.NewTestProbe(t)
+		coordinator := sharding.NewCoordinator(8, nil)
+		passivation := f.PassivationConfig[int]{Store: framework.NewMemoryStateStore[int]()}
+		local, err := sharding.NewShardRegion(framework.NewActorSystem("local"), "counter", coordinator, newCounterFactory(probe), passivation, b.WithLogger(logger))
+		assert.NilError(t, err)
+		remote, err := sharding.NewShardRegion(framework.NewActorSystem("remote"), "counter", coordinator, newCounterFactory(probe), passivation)
+		assert.NilError(t, err)
+		defer remote.Stop()
+
+		var entityID string
+		for i := 0; entityID == ""; i++ {
+			candidate := fmt.Sprintf("entity-%d", i)
+			assert.NilError(t, coordinator.WithOwner(coordinator.ShardOf(candidate), func(owner url.URL) error {
+				if owner == remote.Address() {
+					entityID = candidate
+				}
+				return nil
+			}))
+		}
+
+		assert.NilError(t, local.Deliver(s.ShardEnvelope{EntityID: entityID, Payload: "inc"}, probe))
+		probe.ExpectNoMsg(20 * time.Millisecond)
+		assert.Equal(t, len(remote.Entities()), 0)
+
+		stopCompleted, err := local.Stop()
+		assert.NilError(t, err)
+		<-stopCompleted
+		assert.Assert(t, strings.Contains(buffer.String(), s.ErrorRegionUnreachable.Error()))
+	})
+}
@@ -0,0 +1,193 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	t "github.com/morphy76/lang-actor/pkg/tracing"
+)
+
+var staticOTLPExporterAssertion t.Exporter = (*otlpExporter)(nil)
+
+const (
+	otlpTracesPath = "/v1/traces"
+	scopeName      = "github.com/morphy76/lang-actor"
+
+	spanKindInternal = 1
+	statusCodeOk     = 1
+	statusCodeError  = 2
+)
+
+type otlpExporter struct {
+	client      *http.Client
+	endpoint    string
+	serviceName string
+}
+
+// Export sends a batch of spans to the collector using OTLP/HTTP with JSON encoding.
+func (e *otlpExporter) Export(spans []t.SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans to [%s]: %w", e.endpoint, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to export spans to [%s]: unexpected status [%s]", e.endpoint, resp.Status)
+	}
+
+	return nil
+}
+
+func (e *otlpExporter) request(spans []t.SpanData) otlpRequest {
+	useSpans := make([]otlpSpan, 0, len(spans))
+	for _, data := range spans {
+		useSpan := otlpSpan{
+			TraceID:           data.Context.TraceID.String(),
+			SpanID:            data.Context.SpanID.String(),
+			TraceState:        data.Context.TraceState,
+			Name:              data.Name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(data.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(data.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(data.Attributes),
+			Status:            otlpStatus{Code: statusCodeOk},
+		}
+		if data.Parent.IsValid() {
+			useSpan.ParentSpanID = data.Parent.String()
+		}
+		if data.Err != nil {
+			useSpan.Status = otlpStatus{Code: statusCodeError, Message: data.Err.Error()}
+		}
+		useSpans = append(useSpans, useSpan)
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes([]t.Attribute{{Key: "service.name", Value: e.serviceName}}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: scopeName},
+				Spans: useSpans,
+			}},
+		}},
+	}
+}
+
+// NewOTLPExporter creates a new exporter sending spans to an OTLP/HTTP collector.
+func NewOTLPExporter(endpoint url.URL, serviceName string, client *http.Client) t.Exporter {
+	useEndpoint := endpoint.String()
+	if !strings.HasSuffix(endpoint.Path, otlpTracesPath) {
+		useEndpoint = strings.TrimSuffix(useEndpoint, "/") + otlpTracesPath
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &otlpExporter{
+		client:      client,
+		endpoint:    useEndpoint,
+		serviceName: serviceName,
+	}
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	TraceState        string         `json:"traceState,omitempty"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func otlpAttributes(attributes []t.Attribute) []otlpKeyValue {
+	rv := make([]otlpKeyValue, 0, len(attributes))
+	for _, attr := range attributes {
+		var value otlpValue
+		switch v := attr.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case bool:
+			value.BoolValue = &v
+		case int:
+			i := strconv.Itoa(v)
+			value.IntValue = &i
+		case int64:
+			i := strconv.FormatInt(v, 10)
+			value.IntValue = &i
+		case float64:
+			value.DoubleValue = &v
+		default:
+			s := fmt.Sprintf("%v", v)
+			value.StringValue = &s
+		}
+		rv = append(rv, otlpKeyValue{Key: attr.Key, Value: value})
+	}
+	return rv
+}
@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	t "github.com/morphy76/lang-actor/pkg/tracing"
+)
+
+var staticTracerAssertion t.Tracer = (*tracer)(nil)
+var staticSpanAssertion t.Span = (*span)(nil)
+
+const (
+	defaultBatchSize     = 512
+	defaultQueueSize     = 4096
+	defaultFlushInterval = 5 * time.Second
+)
+
+type tracer struct {
+	exporter t.Exporter
+
+	queue     chan t.SpanData
+	flushReqs chan chan error
+	done      chan struct{}
+	stopped   chan error
+	stopOnce  *sync.Once
+
+	batchSize int
+	interval  time.Duration
+}
+
+// Start starts a new span.
+func (tr *tracer) Start(parent t.SpanContext, name string, attributes ...t.Attribute) t.Span {
+	sc := t.SpanContext{
+		SpanID:  newSpanID(),
+		Sampled: true,
+	}
+	var parentID t.SpanID
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+		sc.TraceState = parent.TraceState
+		parentID = parent.SpanID
+	} else {
+		sc.TraceID = newTraceID()
+	}
+
+	return &span{
+		lock:   &sync.Mutex{},
+		tracer: tr,
+		data: t.SpanData{
+			Name:       name,
+			Context:    sc,
+			Parent:     parentID,
+			Start:      time.Now(),
+			Attributes: append([]t.Attribute(nil), attributes...),
+		},
+	}
+}
+
+// Flush exports the ended spans waiting to be exported.
+func (tr *tracer) Flush() error {
+	reply := make(chan error, 1)
+	select {
+	case tr.flushReqs <- reply:
+		return <-reply
+	case <-tr.done:
+		return nil
+	}
+}
+
+// Shutdown flushes the pending spans and stops the export loop.
+func (tr *tracer) Shutdown() error {
+	tr.stopOnce.Do(func() {
+		close(tr.done)
+	})
+	return <-tr.stopped
+}
+
+func (tr *tracer) enqueue(data t.SpanData) {
+	select {
+	case <-tr.done:
+		return
+	default:
+	}
+	select {
+	case tr.queue <- data:
+	default:
+		// The export queue is full, the span is dropped rather than blocking the caller
+	}
+}
+
+func (tr *tracer) loop() {
+	ticker := time.NewTicker(tr.interval)
+	defer ticker.Stop()
+
+	batch := make([]t.SpanData, 0, tr.batchSize)
+	export := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := tr.exporter.Export(batch)
+		batch = make([]t.SpanData, 0, tr.batchSize)
+		return err
+	}
+	drain := func() {
+		for {
+			select {
+			case data := <-tr.queue:
+				batch = append(batch, data)
+			default:
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case data := <-tr.queue:
+			batch = append(batch, data)
+			if len(batch) >= tr.batchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case reply := <-tr.flushReqs:
+			drain()
+			reply <- export()
+		case <-tr.done:
+			drain()
+			err := export()
+			tr.stopped <- err
+			close(tr.stopped)
+			return
+		}
+	}
+}
+
+type span struct {
+	lock   *sync.Mutex
+	tracer *tracer
+
+	data  t.SpanData
+	ended bool
+}
+
+// Context returns the span context to be propagated to child spans.
+func (s *span) Context() t.SpanContext {
+	return s.data.Context
+}
+
+// SetAttribute sets an attribute on the span.
+func (s *span) SetAttribute(key string, value any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for idx, attr := range s.data.Attributes {
+		if attr.Key == key {
+			s.data.Attributes[idx].Value = value
+			return
+		}
+	}
+	s.data.Attributes = append(s.data.Attributes, t.Attribute{Key: key, Value: value})
+}
+
+// End ends the span and hands it to the export loop when sampled.
+func (s *span) End(err error) {
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	s.data.Err = err
+	data := s.data
+	s.lock.Unlock()
+
+	if data.Context.Sampled {
+		s.tracer.enqueue(data)
+	}
+}
+
+// NewTracer creates a new tracer exporting the ended spans in batches.
+func NewTracer(exporter t.Exporter) t.Tracer {
+	rv := &tracer{
+		exporter: exporter,
+
+		queue:     make(chan t.SpanData, defaultQueueSize),
+		flushReqs: make(chan chan error),
+		done:      make(chan struct{}),
+		stopped:   make(chan error, 1),
+		stopOnce:  &sync.Once{},
+
+		batchSize: defaultBatchSize,
+		interval:  defaultFlushInterval,
+	}
+	go rv.loop()
+
+	return rv
+}
+
+func newTraceID() t.TraceID {
+	var rv t.TraceID
+	for !rv.IsValid() {
+		for idx := range rv {
+			rv[idx] = byte(rand.Uint32())
+		}
+	}
+	return rv
+}
+
+func newSpanID() t.SpanID {
+	var rv t.SpanID
+	for !rv.IsValid() {
+		for idx := range rv {
+			rv[idx] = byte(rand.Uint32())
+		}
+	}
+	return rv
+}
@@ -0,0 +1,121 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/internal/tracing"
+	tr "github.com/morphy76/lang-actor/pkg/tracing"
+)
+
+type recordingExporter struct {
+	lock  sync.Mutex
+	spans []tr.SpanData
+}
+
+func (e *recordingExporter) Export(spans []tr.SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	t.Log("Tracer test suite")
+
+	t.Run("Start root and child spans", func(t *testing.T) {
+		t.Log("Should start a new trace for root spans and keep the trace for child spans")
+
+		exporter := &recordingExporter{}
+		tracer := tracing.NewTracer(exporter)
+
+		root := tracer.Start(tr.SpanContext{}, "root")
+		child := tracer.Start(root.Context(), "child", tr.Attribute{Key: "key", Value: "value"})
+		child.End(errors.New("boom"))
+		root.End(nil)
+
+		assert.NilError(t, tracer.Flush())
+		assert.Equal(t, len(exporter.spans), 2)
+
+		childData, rootData := exporter.spans[0], exporter.spans[1]
+		assert.Equal(t, childData.Context.TraceID, rootData.Context.TraceID)
+		assert.Equal(t, childData.Parent, rootData.Context.SpanID)
+		assert.Assert(t, !rootData.Parent.IsValid())
+		assert.ErrorContains(t, childData.Err, "boom")
+		assert.Equal(t, childData.Attributes[0], tr.Attribute{Key: "key", Value: "value"})
+
+		assert.NilError(t, tracer.Shutdown())
+	})
+
+	t.Run("Do not export unsampled spans", func(t *testing.T) {
+		t.Log("Should not export the spans of an unsampled trace")
+
+		exporter := &recordingExporter{}
+		tracer := tracing.NewTracer(exporter)
+
+		parent, err := tr.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+		assert.NilError(t, err)
+		tracer.Start(parent, "unsampled").End(nil)
+
+		assert.NilError(t, tracer.Shutdown())
+		assert.Equal(t, len(exporter.spans), 0)
+	})
+}
+
+func TestOTLPExporter(t *testing.T) {
+	t.Log("OTLP exporter test suite")
+
+	t.Run("Post spans as OTLP JSON", func(t *testing.T) {
+		t.Log("Should post the spans to the /v1/traces path of the collector")
+
+		var path string
+		var body map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		endpoint, err := url.Parse(server.URL)
+		assert.NilError(t, err)
+
+		exporter := tracing.NewOTLPExporter(*endpoint, "test-service", nil)
+		tracer := tracing.NewTracer(exporter)
+		tracer.Start(tr.SpanContext{}, "span", tr.Attribute{Key: tr.AttributeActorAddress, Value: "actor://example"}).End(nil)
+		assert.NilError(t, tracer.Shutdown())
+
+		assert.Equal(t, path, "/v1/traces")
+		resourceSpans := body["resourceSpans"].([]any)[0].(map[string]any)
+		serviceName := resourceSpans["resource"].(map[string]any)["attributes"].([]any)[0].(map[string]any)
+		assert.Equal(t, serviceName["value"].(map[string]any)["stringValue"], "test-service")
+
+		span := resourceSpans["scopeSpans"].([]any)[0].(map[string]any)["spans"].([]any)[0].(map[string]any)
+		assert.Equal(t, span["name"], "span")
+		assert.Equal(t, len(span["traceId"].(string)), 32)
+		assert.Equal(t, len(span["spanId"].(string)), 16)
+	})
+
+	t.Run("Report collector failures", func(t *testing.T) {
+		t.Log("Should return an error when the collector does not accept the spans")
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		endpoint, err := url.Parse(server.URL + "/v1/traces")
+		assert.NilError(t, err)
+
+		exporter := tracing.NewOTLPExporter(*endpoint, "test-service", nil)
+		err = exporter.Export([]tr.SpanData{{Name: "span"}})
+		assert.ErrorContains(t, err, "unexpected status")
+	})
+}
@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/graph"
+)
+
+// ErrorUnknownMessageType is returned when a message to be sent has a type without decoder.
+var ErrorUnknownMessageType = errors.New("unknown message type")
+
+// Decoder decodes the JSON payload of a message sent through the admin endpoint.
+type Decoder func(payload json.RawMessage) (any, error)
+
+// DecoderFor returns a Decoder unmarshalling the payload into a value of the given type.
+//
+// Type Parameters:
+//   - T: The type of the message.
+//
+// Returns:
+//   - (Decoder): The decoder producing values of type T.
+func DecoderFor[T any]() Decoder {
+	return func(payload json.RawMessage) (any, error) {
+		var rv T
+		if err := json.Unmarshal(payload, &rv); err != nil {
+			return nil, err
+		}
+		return rv, nil
+	}
+}
+
+// Config defines what the admin endpoint exposes.
+type Config struct {
+	// System is the actor system to be inspected and operated
+	System framework.ActorSystem
+	// Graphs are the graphs to be listed, by name
+	Graphs map[string]graph.Graph
+	// Decoders decode the messages sent through the endpoint by type name; payloads without type are sent as generic JSON values
+	Decoders map[string]Decoder
+	// EventBuffer is the number of lifecycle events buffered per event stream, 256 when not positive
+	EventBuffer int
+}
+
+// SendRequest is the body of the requests sending a message to an actor.
+type SendRequest struct {
+	// Address of the destination actor
+	Address string `json:"address"`
+	// Type selects the decoder of the payload, empty for generic JSON values
+	Type string `json:"type,omitempty"`
+	// Payload is the JSON encoded message
+	Payload json.RawMessage `json:"payload"`
+}
+
+// RunRequest is the body of the requests running a graph, the payload being accepted by its root nodes.
+type RunRequest struct {
+	// Graph is the name of the graph
+	Graph string `json:"graph"`
+	// Type selects the decoder of the payload, empty for generic JSON values
+	Type string `json:"type,omitempty"`
+	// Payload is the JSON encoded message
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Event is the JSON representation of a framework.LifecycleEvent streamed over server-sent events.
+type Event struct {
+	// Kind of the change, e.g. "started"
+	Kind string `json:"kind"`
+	// Address of the actor
+	Address string `json:"address"`
+	// Time of the change
+	Time time.Time `json:"time"`
+	// Error of the failures
+	Error string `json:"error,omitempty"`
+}
+
+// GraphInfo describes a graph and the addresses of its nodes.
+type GraphInfo struct {
+	// Name of the graph
+	Name string `json:"name"`
+	// Nodes are the addresses registered in the graph
+	Nodes []string `json:"nodes"`
+}
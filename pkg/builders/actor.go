@@ -18,7 +18,7 @@ import (
 //   - address (url.URL): The address of the actor.
 //   - processingFn (framework.ProcessingFn): The function to process messages sent to the actor.
 //   - initialState (T): The initial state of the actor.
-//   - mailboxConfig (framework.MailboxConfig): Optional configuration for the actor's mailbox.
+//   - options (...framework.ActorOption): Optional settings of the actor, e.g. the mailbox configuration.
 //
 // Returns:
 //   - (framework.Actor): The created Actor instance.
@@ -27,9 +27,9 @@ func NewActor[T any](
 	address url.URL,
 	processingFn framework.ProcessingFn[T],
 	initialState T,
-	mailboxConfig ...framework.MailboxConfig,
+	options ...framework.ActorOption,
 ) (framework.Actor[T], error) {
-	return f.NewActor(address, processingFn, initialState, mailboxConfig...)
+	return f.NewActor(address, processingFn, initialState, options...)
 }
 
 // NewMutableActor creates a new actor with the given address alwways mutable.
@@ -43,7 +43,7 @@ func NewActor[T any](
 //   - address (url.URL): The address of the actor.
 //   - processingFn (framework.ProcessingFn): The function to process messages sent to the actor.
 //   - initialState (T): The initial state of the actor.
-//   - mailboxConfig (framework.MailboxConfig): Optional configuration for the actor's mailbox.
+//   - options (...framework.ActorOption): Optional settings of the actor, e.g. the mailbox configuration.
 //
 // Returns:
 //   - (framework.Actor): The created Actor instance.
@@ -52,9 +52,9 @@ func NewMutableActor[T any](
 	address url.URL,
 	processingFn framework.ProcessingFn[T],
 	initialState T,
-	mailboxConfig ...framework.MailboxConfig,
+	options ...framework.ActorOption,
 ) (framework.Actor[T], error) {
-	return f.NewActor(address, processingFn, initialState, mailboxConfig...)
+	return f.NewActor(address, processingFn, initialState, options...)
 }
 
 // SpawnChild creates a new child actor with the given processing function and initial state.
@@ -67,7 +67,7 @@ func NewMutableActor[T any](
 //   - processingFn (framework.ProcessingFn): The function to process messages sent to the child actor.
 //   - initialState (T): The initial state of the child actor.
 //   - parent (framework.ActorRef): The parent actor reference.
-//   - mailboxConfig (framework.MailboxConfig): Optional configuration for the child's mailbox.
+//   - options (...framework.ActorOption): Optional settings of the child, e.g. the mailbox configuration.
 //
 // Returns:
 //   - (framework.Actor): The created child Actor instance.
@@ -76,9 +76,9 @@ func SpawnChild[T any](
 	parent framework.ActorRef,
 	processingFn framework.ProcessingFn[T],
 	initialState T,
-	mailboxConfig ...framework.MailboxConfig,
+	options ...framework.ActorOption,
 ) (framework.Actor[T], error) {
-	child, err := f.NewActorWithParent(processingFn, initialState, parent, mailboxConfig...)
+	child, err := f.NewActorWithParent(processingFn, initialState, parent, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -2,13 +2,30 @@ package builders
 
 import (
 	r "github.com/morphy76/lang-actor/internal/routing"
+	"github.com/morphy76/lang-actor/pkg/framework"
 	"github.com/morphy76/lang-actor/pkg/routing"
 )
 
 // NewAddressBook creates a new actor catalog.
 //
+// Parameters:
+//   - clock (...framework.Clock): The clock measuring the leases, the wall clock when not given.
+//
 // Returns:
 //   - (routing.AddressBook): The created AddressBook instance.
-func NewAddressBook() routing.AddressBook {
-	return r.NewAddressBook()
+func NewAddressBook(clock ...framework.Clock) routing.AddressBook {
+	return r.NewAddressBook(clock...)
+}
+
+// WithAddressBook registers the actor, and its children, in the address book when created and unregisters it when stopped.
+//
+// Parameters:
+//   - addressBook (routing.AddressBook): The address book the actor registers in.
+//
+// Returns:
+//   - (framework.ActorOption): The option setting the address book of the actor.
+func WithAddressBook(addressBook routing.AddressBook) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.AddressBook = addressBook
+	})
 }
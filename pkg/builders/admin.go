@@ -0,0 +1,20 @@
+package builders
+
+import (
+	"net/http"
+
+	ia "github.com/morphy76/lang-actor/internal/admin"
+	"github.com/morphy76/lang-actor/pkg/admin"
+)
+
+// NewAdminHandler creates an HTTP handler to inspect and operate a running actor system, to be mounted on a mux.
+//
+// Parameters:
+//   - config (admin.Config): The actor system, the graphs and the message decoders exposed by the handler.
+//
+// Returns:
+//   - (http.Handler): The handler serving the /actors, /graphs and /events routes, relative to its mount point.
+//   - (error): An error if the actor system is missing.
+func NewAdminHandler(config admin.Config) (http.Handler, error) {
+	return ia.NewHandler(config)
+}
@@ -0,0 +1,42 @@
+package builders
+
+import (
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// NewSystemClock creates a clock reading the wall time, the default clock of the actors.
+//
+// Returns:
+//   - (framework.Clock): The created Clock instance.
+func NewSystemClock() framework.Clock {
+	return f.NewSystemClock()
+}
+
+// NewScheduler creates a scheduler delivering messages in the future.
+//
+// Parameters:
+//   - clock (...framework.Clock): Optional clock timing the deliveries, the wall clock by default.
+//
+// Returns:
+//   - (framework.Scheduler): The created Scheduler instance.
+func NewScheduler(clock ...framework.Clock) framework.Scheduler {
+	var useClock framework.Clock
+	if len(clock) > 0 {
+		useClock = clock[0]
+	}
+	return f.NewScheduler(useClock)
+}
+
+// WithClock makes the actor measure time with the given clock, e.g. the drain timeout on stop.
+//
+// Parameters:
+//   - clock (framework.Clock): The source of time.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor, the graph or the actor system.
+func WithClock(clock framework.Clock) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.Clock = clock
+	})
+}
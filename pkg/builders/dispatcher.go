@@ -0,0 +1,41 @@
+package builders
+
+import (
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// WithDispatcher makes the actor run its messages on the given dispatcher instead of a dedicated goroutine.
+//
+// Parameters:
+//   - dispatcher (framework.Dispatcher): The dispatcher, nil pins the actor to a dedicated goroutine.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor, the graph or the actor system.
+func WithDispatcher(dispatcher framework.Dispatcher) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.Dispatcher = dispatcher
+	})
+}
+
+// NewPoolDispatcher creates a dispatcher running the actors with pending messages on a bounded set of shared goroutines.
+//
+// Parameters:
+//   - workers (int): The number of goroutines shared by the actors.
+//   - throughput (int): The number of messages an actor processes before yielding its goroutine to the next actor.
+//
+// Returns:
+//   - (framework.PoolDispatcher): The created PoolDispatcher instance, to be shut down once its actors are stopped.
+//
+// Processing functions run on the shared goroutines, they should not block waiting for other actors of the same pool.
+func NewPoolDispatcher(workers int, throughput int) framework.PoolDispatcher {
+	return f.NewPoolDispatcher(workers, throughput)
+}
+
+// WithPinnedDispatcher makes the actor run its messages on a dedicated goroutine, overriding the dispatcher of its system or parent.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor.
+func WithPinnedDispatcher() framework.ActorOption {
+	return WithDispatcher(nil)
+}
@@ -0,0 +1,21 @@
+package builders
+
+import (
+	ifsm "github.com/morphy76/lang-actor/internal/fsm"
+	"github.com/morphy76/lang-actor/pkg/fsm"
+)
+
+// NewFSM creates the builder of a finite state machine, to be run by an actor.
+//
+// Type Parameters:
+//   - D: The type of the data carried across the states.
+//
+// Parameters:
+//   - initial (string): The name of the initial state.
+//   - data (D): The initial data.
+//
+// Returns:
+//   - (fsm.Builder[D]): The builder of the machine.
+func NewFSM[D any](initial string, data D) fsm.Builder[D] {
+	return ifsm.NewBuilder(initial, data)
+}
@@ -0,0 +1,48 @@
+package builders
+
+import (
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// RegisterGrainKind registers in the actor system how the grains of a kind are activated.
+//
+// Type Parameters:
+//   - T: The type of the grain state.
+//
+// Parameters:
+//   - system (framework.ActorSystem): The actor system hosting the grains.
+//   - kind (string): The kind of the grains.
+//   - factory (framework.ActorFactory[T]): The function creating the processing function and the initial state of an activation.
+//   - passivation (framework.PassivationConfig[T]): The idle timeout and the optional state store of the grains.
+//   - options (...framework.ActorOption): Optional settings of the activations, applied after the system options.
+//
+// Returns:
+//   - (error): An error if the kind is already registered.
+func RegisterGrainKind[T any](
+	system framework.ActorSystem,
+	kind string,
+	factory framework.ActorFactory[T],
+	passivation framework.PassivationConfig[T],
+	options ...framework.ActorOption,
+) error {
+	return system.RegisterKind(kind, f.NewGrainActivator(factory, passivation, options...))
+}
+
+// Grain returns the reference of a virtual actor, which always works: the first message activates the actor.
+// The reference is registered in the address book of the system at actor://<system>/<kind>/<id>.
+//
+// Type Parameters:
+//   - T: The type of the grain state.
+//
+// Parameters:
+//   - system (framework.ActorSystem): The actor system hosting the grain.
+//   - kind (string): The registered kind of the grain.
+//   - id (string): The identity of the grain within its kind.
+//
+// Returns:
+//   - (framework.PassivatingActor[T]): The reference of the grain.
+//   - (error): An error if the kind is unknown or registered with another state type.
+func Grain[T any](system framework.ActorSystem, kind string, id string) (framework.PassivatingActor[T], error) {
+	return f.Grain[T](system, kind, id)
+}
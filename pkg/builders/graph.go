@@ -2,6 +2,7 @@ package builders
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -19,6 +20,7 @@ import (
 // Parameters:
 //   - initialState (T graph.State): The initial state of the graph.
 //   - configs (C graph.Configuration): Optional configurations for the graph.
+//   - options (...framework.ActorOption): Optional settings applied to the actors of every node, e.g. the tracer.
 //
 // Returns:
 //   - (graph.Graph): The created actor graph.
@@ -26,8 +28,22 @@ import (
 func NewGraph[T graph.State, C graph.Configuration](
 	initialState T,
 	configs C,
+	options ...framework.ActorOption,
 ) (graph.Graph, error) {
-	return g.NewGraph(uuid.NewString(), initialState, configs)
+	return g.NewGraph(uuid.NewString(), initialState, configs, options...)
+}
+
+// WithNodeTimeout bounds the wait of every node of the graph for the outcome of its task.
+//
+// The outcomes produced after their wait timed out are discarded, they never route a later message.
+//
+// Parameters:
+//   - timeout (time.Duration): The maximum wait, measured with the clock of the node actors; zero waits forever.
+//
+// Returns:
+//   - (graph.GraphOption): The option to be used when creating the graph.
+func WithNodeTimeout(timeout time.Duration) graph.GraphOption {
+	return graph.NodeTimeout(timeout)
 }
 
 // NewRootNode creates a new instance of the root node.
@@ -0,0 +1,28 @@
+package builders
+
+import (
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// Inspect walks the actor hierarchies from the given roots.
+//
+// Parameters:
+//   - roots (...framework.ActorRef): The actors to start from, e.g. the actors created without a parent.
+//
+// Returns:
+//   - (framework.ActorTree): The snapshot of the hierarchies, rendered by its JSON and String methods.
+func Inspect(roots ...framework.ActorRef) framework.ActorTree {
+	return f.Inspect(roots...)
+}
+
+// InspectSystem walks the actor hierarchies of an actor system.
+//
+// Parameters:
+//   - system (framework.ActorSystem): The actor system whose roots are inspected.
+//
+// Returns:
+//   - (framework.ActorTree): The snapshot of the hierarchies of the actors created within the system.
+func InspectSystem(system framework.ActorSystem) framework.ActorTree {
+	return f.Inspect(system.Roots()...)
+}
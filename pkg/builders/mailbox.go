@@ -68,3 +68,27 @@ func NewMailboxConfigWithDropOldestPolicy(capacity int) framework.MailboxConfig
 		Policy:   framework.BackpressurePolicyDropOldest,
 	}
 }
+
+// NewMailboxConfigWithThrottle adds a token bucket throttle to a mailbox configuration.
+//
+// Parameters:
+//   - mailbox (framework.MailboxConfig): The mailbox configuration, providing the capacity and the backpressure policy.
+//   - rate (float64): The number of messages per second reaching the processing function.
+//   - burst (int): The number of messages processed at once after an idle period.
+//   - overflow (framework.ThrottleOverflow): How the messages exceeding the rate are handled: queued, dropped or rejected.
+//
+// Returns:
+//   - (framework.MailboxConfig): The created MailboxConfig instance.
+func NewMailboxConfigWithThrottle(
+	mailbox framework.MailboxConfig,
+	rate float64,
+	burst int,
+	overflow framework.ThrottleOverflow,
+) framework.MailboxConfig {
+	mailbox.Throttle = framework.ThrottleConfig{
+		Rate:     rate,
+		Burst:    burst,
+		Overflow: overflow,
+	}
+	return mailbox
+}
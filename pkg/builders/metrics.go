@@ -0,0 +1,31 @@
+package builders
+
+import (
+	im "github.com/morphy76/lang-actor/internal/metrics"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/metrics"
+)
+
+// NewMetricsRegistry creates a new metrics registry exposing the Prometheus text format.
+//
+// Parameters:
+//   - buckets (...float64): Optional upper bounds, in seconds, of the processing latency histogram.
+//
+// Returns:
+//   - (metrics.Registry): The created Registry instance.
+func NewMetricsRegistry(buckets ...float64) metrics.Registry {
+	return im.NewRegistry(buckets...)
+}
+
+// WithMetrics instruments the actor and its mailbox with the given metrics.
+//
+// Parameters:
+//   - metrics (framework.Metrics): The metrics recorder, e.g. a metrics.Registry.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor.
+func WithMetrics(metrics framework.Metrics) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.Metrics = metrics
+	})
+}
@@ -0,0 +1,49 @@
+package builders
+
+import (
+	"time"
+
+	r "github.com/morphy76/lang-actor/internal/routing"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/routing"
+)
+
+// NewFileBackend creates a name service backend storing the registrations in a file shared by the processes of a host.
+//
+// Parameters:
+//   - path (string): The path of the registry, YAML when ending with ".yaml" or ".yml", JSON otherwise.
+//   - pollInterval (time.Duration): The interval of the checks for changes of the file, one second when not positive.
+//   - leaseTTL (...time.Duration): Optional lease of the registrations of this process, thirty seconds by default;
+//     renewed until the backend is closed, the registrations of a crashed process are ignored once it expires.
+//
+// Returns:
+//   - (routing.Backend): The created Backend instance.
+//   - (error): An error if the directory of the registry cannot be created.
+func NewFileBackend(path string, pollInterval time.Duration, leaseTTL ...time.Duration) (routing.Backend, error) {
+	return r.NewFileBackend(path, pollInterval, leaseTTL...)
+}
+
+// NewNameService creates a resolver sharing its registrations through a backend.
+//
+// Parameters:
+//   - backend (routing.Backend): The backend storing the registrations.
+//   - endpoint (string): Where this process can be reached, stored along with its registrations.
+//   - options (...framework.ActorOption): Optional settings, e.g. WithLogger for the diagnostics.
+//
+// Returns:
+//   - (routing.NameService): The created NameService instance, following the backend changes until closed.
+//   - (error): An error if the backend cannot be read.
+func NewNameService(backend routing.Backend, endpoint string, options ...framework.ActorOption) (routing.NameService, error) {
+	return r.NewNameService(backend, endpoint, options...)
+}
+
+// NewCompositeResolver creates a resolver chaining, for each scheme, the given resolvers in order.
+//
+// Parameters:
+//   - chains (map[string][]routing.Resolver): The resolvers of each scheme, e.g. the local address book before a name service.
+//
+// Returns:
+//   - (routing.Resolver): The created Resolver instance.
+func NewCompositeResolver(chains map[string][]routing.Resolver) routing.Resolver {
+	return r.NewCompositeResolver(chains)
+}
@@ -0,0 +1,46 @@
+package builders
+
+import (
+	"net/url"
+
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// NewPassivatingActor creates a new actor reference which drops its actor from memory when idle.
+// The actor is created by the factory on the first message and again on the first message after each passivation,
+// with the state kept by the state store of the passivation configuration.
+// Supported schemas are:
+// - actor://
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//
+// Parameters:
+//   - address (url.URL): The address of the actor, unchanged across the incarnations.
+//   - factory (framework.ActorFactory[T]): The function creating the processing function and the initial state of an incarnation.
+//   - passivation (framework.PassivationConfig[T]): The idle timeout and the optional state store.
+//   - options (...framework.ActorOption): Optional settings applied to every incarnation, e.g. the clock measuring the idle time.
+//
+// Returns:
+//   - (framework.PassivatingActor[T]): The created PassivatingActor instance.
+//   - (error): An error if the actor reference could not be created.
+func NewPassivatingActor[T any](
+	address url.URL,
+	factory framework.ActorFactory[T],
+	passivation framework.PassivationConfig[T],
+	options ...framework.ActorOption,
+) (framework.PassivatingActor[T], error) {
+	return f.NewPassivatingActor(address, factory, passivation, options...)
+}
+
+// NewMemoryStateStore creates a state store keeping the states of the passivated actors in memory.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//
+// Returns:
+//   - (framework.StateStore[T]): The created StateStore instance.
+func NewMemoryStateStore[T any]() framework.StateStore[T] {
+	return f.NewMemoryStateStore[T]()
+}
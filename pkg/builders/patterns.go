@@ -0,0 +1,56 @@
+package builders
+
+import (
+	"time"
+
+	ip "github.com/morphy76/lang-actor/internal/patterns"
+	"github.com/morphy76/lang-actor/pkg/common"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/patterns"
+)
+
+// ScatterGather sends a patterns.Request to every responder and gathers their replies.
+//
+// Responders answer with patterns.Respond; the gathering completes when every responder replied, when the
+// quorum of successful replies is reached or cannot be reached anymore, or at the deadline.
+//
+// Parameters:
+//   - payload (any): The payload of the request.
+//   - responders ([]framework.ActorRef): The actors receiving the request.
+//   - requester (common.Transport): The transport receiving the patterns.Gathered result, nil to only use the returned channel.
+//   - config (patterns.GatherConfig): The quorum, the deadline and the options providing the clock.
+//
+// Returns:
+//   - (<-chan patterns.Gathered): The channel receiving the patterns.Gathered result.
+//   - (error): An error if the gathering cannot be created.
+func ScatterGather(payload any, responders []framework.ActorRef, requester common.Transport, config patterns.GatherConfig) (<-chan patterns.Gathered, error) {
+	return ip.ScatterGather(payload, responders, requester, config)
+}
+
+// Ask sends a patterns.Request to an actor and waits for its patterns.Reply.
+//
+// Waiting blocks the caller, processing functions should ask through PipeTo to keep their actor responsive.
+//
+// Parameters:
+//   - payload (any): The payload of the request.
+//   - responder (framework.ActorRef): The actor answering with patterns.Respond.
+//   - timeout (time.Duration): The maximum time to wait for the reply, zero waits without deadline.
+//   - options (...framework.ActorOption): Optional settings, e.g. the clock measuring the deadline.
+//
+// Returns:
+//   - (any): The payload of the reply.
+//   - (error): The error of the reply, or patterns.ErrorNoReply at the deadline.
+func Ask(payload any, responder framework.ActorRef, timeout time.Duration, options ...framework.ActorOption) (any, error) {
+	return ip.Ask(payload, responder, timeout, options...)
+}
+
+// NewCircuitBreaker creates a closed circuit breaker, protecting function calls and asks.
+//
+// Parameters:
+//   - config (patterns.BreakerConfig): The failure threshold, the reset timeout, the trial calls and the options providing the clock.
+//
+// Returns:
+//   - (patterns.CircuitBreaker): The created CircuitBreaker instance.
+func NewCircuitBreaker(config patterns.BreakerConfig) patterns.CircuitBreaker {
+	return ip.NewCircuitBreaker(config)
+}
@@ -0,0 +1,21 @@
+package builders
+
+import (
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/common"
+)
+
+// PipeTo runs slow work, e.g. I/O, off the actor goroutine and delivers its outcome back as a framework.Piped[R] message.
+//
+// The actor keeps processing its mailbox while the work runs; the work must not read or change the actor state,
+// which is only updated when the outcome is processed.
+//
+// Type Parameters:
+//   - R: The type of the result of the work.
+//
+// Parameters:
+//   - self (common.Transport): The actor receiving the outcome, usually the self of the processing function.
+//   - work (func() (R, error)): The work to be run.
+func PipeTo[R any](self common.Transport, work func() (R, error)) {
+	f.PipeTo(self, work)
+}
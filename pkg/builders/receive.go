@@ -0,0 +1,42 @@
+package builders
+
+import (
+	"log/slog"
+
+	ir "github.com/morphy76/lang-actor/internal/receive"
+	"github.com/morphy76/lang-actor/pkg/common"
+	"github.com/morphy76/lang-actor/pkg/receive"
+)
+
+// Receive creates the builder of a behavior dispatching the messages to the cases built with receive.OnType and receive.OnMatch.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//
+// Returns:
+//   - (receive.Builder[T]): The builder, logging the unhandled messages as warnings unless configured otherwise.
+func Receive[T any]() receive.Builder[T] {
+	return ir.NewBuilder[T]()
+}
+
+// LogSink creates a sink logging the unhandled messages through the logger of the actor.
+//
+// Parameters:
+//   - level (slog.Level): The level of the log records.
+//
+// Returns:
+//   - (receive.Sink): The sink.
+func LogSink(level slog.Level) receive.Sink {
+	return ir.NewLogSink(level)
+}
+
+// DeadLetterSink creates a sink forwarding the unhandled messages as receive.DeadLetter messages.
+//
+// Parameters:
+//   - deadLetters (common.Transport): The destination of the dead letters, e.g. a monitoring actor.
+//
+// Returns:
+//   - (receive.Sink): The sink.
+func DeadLetterSink(deadLetters common.Transport) receive.Sink {
+	return ir.NewDeadLetterSink(deadLetters)
+}
@@ -0,0 +1,47 @@
+package builders
+
+import (
+	"net/url"
+
+	ir "github.com/morphy76/lang-actor/internal/reliable"
+	"github.com/morphy76/lang-actor/pkg/common"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/reliable"
+)
+
+// NewReliableProducer creates a producer delivering its messages at least once to the consumer.
+//
+// Parameters:
+//   - address (url.URL): The address of the producer, identifying its messages in the outbox and by the consumer.
+//   - consumer (common.Transport): The consumer, processing with a function wrapped by NewReliableConsumer.
+//   - config (reliable.ProducerConfig): The outbox, the redelivery backoff and the options providing the clock.
+//
+// Returns:
+//   - (reliable.Producer): The created Producer instance, redelivering the messages pending in the outbox.
+//   - (error): An error if the outbox cannot be read.
+func NewReliableProducer(address url.URL, consumer common.Transport, config reliable.ProducerConfig) (reliable.Producer, error) {
+	return ir.NewProducer(address, consumer, config)
+}
+
+// NewReliableConsumer wraps a processing function to acknowledge the reliable messages and discard the duplicates.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//
+// Parameters:
+//   - processingFn (framework.ProcessingFn[T]): The function processing the published payloads.
+//   - window (int): The number of sequence numbers accepted per producer beyond the last contiguous one, acknowledged as reported by the producer, 1024 when not positive; the later ones are left to the redeliveries.
+//
+// Returns:
+//   - (framework.ProcessingFn[T]): The wrapped processing function, to be used by a single actor.
+func NewReliableConsumer[T any](processingFn framework.ProcessingFn[T], window int) framework.ProcessingFn[T] {
+	return ir.NewConsumer(processingFn, window)
+}
+
+// NewMemoryOutbox creates an outbox keeping the messages pending acknowledgement in memory.
+//
+// Returns:
+//   - (reliable.Outbox): The created Outbox instance.
+func NewMemoryOutbox() reliable.Outbox {
+	return ir.NewMemoryOutbox()
+}
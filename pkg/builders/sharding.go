@@ -0,0 +1,73 @@
+package builders
+
+import (
+	is "github.com/morphy76/lang-actor/internal/sharding"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/routing"
+	"github.com/morphy76/lang-actor/pkg/sharding"
+)
+
+// NewHashRing creates a consistent hashing ring.
+//
+// Parameters:
+//   - virtualNodes (int): The number of points of each member on the ring, 100 when not positive.
+//
+// Returns:
+//   - (sharding.HashRing): The created HashRing instance.
+func NewHashRing(virtualNodes int) sharding.HashRing {
+	return is.NewHashRing(virtualNodes)
+}
+
+// NewShardCoordinator creates the coordinator allocating a fixed number of shards to the joined shard regions.
+//
+// Parameters:
+//   - shards (int): The number of shards the entities are hashed to, it must not change while regions are running.
+//   - ring (...sharding.HashRing): Optional ring allocating the shards to the regions, a ring of 100 virtual nodes by default.
+//
+// Returns:
+//   - (sharding.Coordinator): The created Coordinator instance, shared by the regions of every actor system of the process.
+func NewShardCoordinator(shards int, ring ...sharding.HashRing) sharding.Coordinator {
+	var useRing sharding.HashRing
+	if len(ring) > 0 {
+		useRing = ring[0]
+	}
+	return is.NewCoordinator(shards, useRing)
+}
+
+// NewShardRegion creates the shard region hosting the entities of the given type in the actor system and joins the coordinator.
+//
+// Type Parameters:
+//   - T: The type of the entity state.
+//
+// Parameters:
+//   - system (framework.ActorSystem): The actor system hosting the region.
+//   - typeName (string): The type of the entities.
+//   - coordinator (sharding.Coordinator): The coordinator allocating the shards.
+//   - factory (framework.ActorFactory[T]): The function creating the processing function and the initial state of an entity.
+//   - passivation (framework.PassivationConfig[T]): The idle timeout and the state store of the entities, shared by the regions to hand the state over.
+//   - options (...framework.ActorOption): Optional settings of the region and its entities, e.g. the dispatcher, and WithRegionResolver.
+//
+// Returns:
+//   - (sharding.ShardRegion): The created ShardRegion instance, accepting sharding.ShardEnvelope messages.
+//   - (error): An error if the region cannot be registered or join the coordinator.
+func NewShardRegion[T any](
+	system framework.ActorSystem,
+	typeName string,
+	coordinator sharding.Coordinator,
+	factory framework.ActorFactory[T],
+	passivation framework.PassivationConfig[T],
+	options ...framework.ActorOption,
+) (sharding.ShardRegion, error) {
+	return is.NewShardRegion(system, typeName, coordinator, factory, passivation, options...)
+}
+
+// WithRegionResolver sets the resolver the shard region registers in and resolves the owners of the shards through.
+//
+// Parameters:
+//   - resolver (routing.Resolver): The resolver shared by the regions, e.g. a name service reaching the regions through their transport.
+//
+// Returns:
+//   - (sharding.RegionOption): The option to be used when creating the shard region, the system address book is used when not given.
+func WithRegionResolver(resolver routing.Resolver) sharding.RegionOption {
+	return sharding.RegionResolver{Resolver: resolver}
+}
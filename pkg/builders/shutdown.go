@@ -0,0 +1,42 @@
+package builders
+
+import (
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// NewCoordinatedShutdown creates a coordinated shutdown running its tasks phase by phase.
+//
+// Parameters:
+//   - config (framework.ShutdownConfig): The phases, framework.DefaultShutdownPhases when empty, the default timeout and the options providing the clock and the logger.
+//
+// Returns:
+//   - (framework.CoordinatedShutdown): The created CoordinatedShutdown instance.
+//   - (error): An error if two phases have the same name.
+func NewCoordinatedShutdown(config framework.ShutdownConfig) (framework.CoordinatedShutdown, error) {
+	return f.NewCoordinatedShutdown(config)
+}
+
+// StopActorsTask creates a shutdown task stopping the actors, in order, and waiting for their termination.
+//
+// Parameters:
+//   - actors (...framework.ActorRef): The actors to be stopped, the ones already stopped are skipped.
+//
+// Returns:
+//   - (framework.ShutdownTask): The task, to be added to a phase such as framework.PhaseStopWorkers.
+func StopActorsTask(actors ...framework.ActorRef) framework.ShutdownTask {
+	return f.NewStopActorsTask(func() []framework.ActorRef {
+		return actors
+	})
+}
+
+// StopSystemTask creates a shutdown task stopping the root actors of an actor system, and so their children.
+//
+// Parameters:
+//   - system (framework.ActorSystem): The actor system, its roots are read when the task runs.
+//
+// Returns:
+//   - (framework.ShutdownTask): The task, to be added to a phase such as framework.PhaseStopWorkers.
+func StopSystemTask(system framework.ActorSystem) framework.ShutdownTask {
+	return f.NewStopActorsTask(system.Roots)
+}
@@ -0,0 +1,69 @@
+package builders
+
+import (
+	"log/slog"
+
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// NewActorSystem creates a new actor system sharing the given options among its actors.
+//
+// Parameters:
+//   - name (string): The name of the actor system.
+//   - options (...framework.ActorOption): The options applied to every actor of the system, e.g. the logger.
+//
+// Returns:
+//   - (framework.ActorSystem): The created ActorSystem instance.
+func NewActorSystem(name string, options ...framework.ActorOption) framework.ActorSystem {
+	return f.NewActorSystem(name, options...)
+}
+
+// WithSystem makes the actor part of the given actor system, inheriting its options.
+//
+// Parameters:
+//   - system (framework.ActorSystem): The actor system.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor or the graph.
+func WithSystem(system framework.ActorSystem) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.System = system
+	})
+}
+
+// WithLogger routes the framework diagnostics of the actor to the given logger.
+//
+// Parameters:
+//   - logger (*slog.Logger): The logger receiving the diagnostics.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor, the graph or the actor system.
+func WithLogger(logger *slog.Logger) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.Logger = logger
+	})
+}
+
+// WithInterceptors wraps the processing of every message of the actor with the given interceptors.
+//
+// The interceptors are added to the ones inherited from the actor system or the parent, which run first.
+//
+// Parameters:
+//   - interceptors (...framework.Interceptor): The interceptors, outermost first.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor or the actor system.
+func WithInterceptors(interceptors ...framework.Interceptor) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.Interceptors = append(append([]framework.Interceptor(nil), config.Interceptors...), interceptors...)
+	})
+}
+
+// RecoverInterceptor creates an interceptor turning the panics of the processing into framework.ErrorProcessingPanic errors.
+//
+// Returns:
+//   - (framework.Interceptor): The interceptor, leaving the state unchanged when the processing panics.
+func RecoverInterceptor() framework.Interceptor {
+	return f.NewRecoverInterceptor()
+}
@@ -0,0 +1,51 @@
+package builders
+
+import (
+	"net/http"
+	"net/url"
+
+	it "github.com/morphy76/lang-actor/internal/tracing"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/tracing"
+)
+
+// NewTracer creates a new tracer exporting the ended spans in batches.
+//
+// Parameters:
+//   - exporter (tracing.Exporter): The exporter receiving the ended spans.
+//
+// Returns:
+//   - (tracing.Tracer): The created Tracer instance, to be shut down to flush the pending spans.
+func NewTracer(exporter tracing.Exporter) tracing.Tracer {
+	return it.NewTracer(exporter)
+}
+
+// NewOTLPExporter creates a new exporter sending spans to an OTLP/HTTP collector using JSON encoding.
+//
+// Parameters:
+//   - endpoint (*url.URL): The collector endpoint, e.g. http://localhost:4318; /v1/traces is appended when missing.
+//   - serviceName (string): The service.name resource attribute of the exported spans.
+//   - client (...*http.Client): Optional HTTP client, http.DefaultClient by default.
+//
+// Returns:
+//   - (tracing.Exporter): The created Exporter instance.
+func NewOTLPExporter(endpoint *url.URL, serviceName string, client ...*http.Client) tracing.Exporter {
+	var useClient *http.Client
+	if len(client) > 0 {
+		useClient = client[0]
+	}
+	return it.NewOTLPExporter(*endpoint, serviceName, useClient)
+}
+
+// WithTracer creates a span around each message processed by the actor.
+//
+// Parameters:
+//   - tracer (tracing.Tracer): The tracer creating the spans.
+//
+// Returns:
+//   - (framework.ActorOption): The option to be used when creating the actor or the graph.
+func WithTracer(tracer tracing.Tracer) framework.ActorOption {
+	return framework.ActorOptionFn(func(config *framework.ActorConfig) {
+		config.Tracer = tracer
+	})
+}
@@ -0,0 +1,65 @@
+package builders
+
+import (
+	"net/url"
+
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// NewTypedActor creates a new actor with the given address, accepting the messages of a single type.
+// Supported schemas are:
+// - actor://
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//   - M: The type of the messages accepted by the actor.
+//
+// Parameters:
+//   - address (url.URL): The address of the actor.
+//   - processingFn (framework.TypedProcessingFn): The function to process the messages sent to the actor.
+//   - initialState (T): The initial state of the actor.
+//   - options (...framework.ActorOption): Optional settings of the actor, e.g. the mailbox configuration.
+//
+// Returns:
+//   - (framework.TypedActor): The created TypedActor instance.
+//   - (error): An error if the actor could not be created.
+func NewTypedActor[T any, M any](
+	address url.URL,
+	processingFn framework.TypedProcessingFn[T, M],
+	initialState T,
+	options ...framework.ActorOption,
+) (framework.TypedActor[T, M], error) {
+	return f.NewTypedActor(address, processingFn, initialState, options...)
+}
+
+// Typed adapts an untyped actor reference, e.g. resolved from an address book, to a typed one.
+//
+// Type Parameters:
+//   - M: The type of the messages accepted by the actor.
+//
+// Parameters:
+//   - ref (framework.ActorRef): The untyped reference.
+//
+// Returns:
+//   - (framework.TypedRef[M]): The typed reference to the same actor.
+func Typed[M any](ref framework.ActorRef) framework.TypedRef[M] {
+	return f.NewTypedRef[M](ref)
+}
+
+// TypedBehavior adapts a typed processing function to an untyped one, e.g. to spawn typed children.
+//
+// The payloads of other types fail the processing with framework.ErrorUnexpectedMessage.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//   - M: The type of the messages accepted by the actor.
+//
+// Parameters:
+//   - processingFn (framework.TypedProcessingFn): The typed processing function.
+//
+// Returns:
+//   - (framework.ProcessingFn[T]): The untyped processing function.
+func TypedBehavior[T any, M any](processingFn framework.TypedProcessingFn[T, M]) framework.ProcessingFn[T] {
+	return f.NewTypedProcessingFn(processingFn)
+}
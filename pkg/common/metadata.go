@@ -0,0 +1,46 @@
+package common
+
+// Metadata carries out-of-band key/value pairs along with a message, e.g. the trace context.
+type Metadata map[string]string
+
+// Envelope wraps a payload with its metadata; transports unwrap it and deliver the bare payload.
+type Envelope struct {
+	// Payload is the message to be delivered
+	Payload any
+	// Metadata is delivered along with the payload
+	Metadata Metadata
+}
+
+// Clone returns a copy of the metadata, safe to be modified.
+//
+// Returns:
+//   - (Metadata): The copy of the metadata, never nil.
+func (m Metadata) Clone() Metadata {
+	rv := make(Metadata, len(m))
+	for key, value := range m {
+		rv[key] = value
+	}
+	return rv
+}
+
+// Unwrap splits a message into its payload and metadata.
+//
+// Parameters:
+//   - msg (any): The message, either an Envelope or a bare payload; a nil *Envelope is a bare payload.
+//
+// Returns:
+//   - (any): The payload of the message.
+//   - (Metadata): The metadata of the message, nil for bare payloads.
+func Unwrap(msg any) (any, Metadata) {
+	switch envelope := msg.(type) {
+	case Envelope:
+		return envelope.Payload, envelope.Metadata
+	case *Envelope:
+		if envelope == nil {
+			return msg, nil
+		}
+		return envelope.Payload, envelope.Metadata
+	default:
+		return msg, nil
+	}
+}
@@ -2,6 +2,7 @@ package framework
 
 import (
 	"errors"
+	"log/slog"
 	"net/url"
 
 	"github.com/morphy76/lang-actor/pkg/common"
@@ -16,6 +17,9 @@ var ErrorActorNotRunning = errors.New("actor not running")
 // ErrorInvalidChildURL is returned when a child URL is invalid.
 var ErrorInvalidChildURL = errors.New("invalid child URL")
 
+// ErrorThrottled is returned when a throttled mailbox rejects a message exceeding its rate.
+var ErrorThrottled = errors.New("message rate exceeded")
+
 // ActorStatus represents the status of an actor.
 type ActorStatus int8
 
@@ -46,6 +50,48 @@ const (
 	BackpressurePolicyDropOldest
 )
 
+// String returns the name of the backpressure policy.
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressurePolicyBlock:
+		return "block"
+	case BackpressurePolicyFail:
+		return "fail"
+	case BackpressurePolicyUnbounded:
+		return "unbounded"
+	case BackpressurePolicyDropNewest:
+		return "drop_newest"
+	case BackpressurePolicyDropOldest:
+		return "drop_oldest"
+	default:
+		return "unknown"
+	}
+}
+
+// ThrottleOverflow defines how a throttled mailbox handles the messages exceeding its rate
+type ThrottleOverflow int
+
+const (
+	// ThrottleOverflowQueue keeps the excess messages in the mailbox until the rate allows their processing
+	ThrottleOverflowQueue ThrottleOverflow = iota
+
+	// ThrottleOverflowDrop silently discards the excess messages
+	ThrottleOverflowDrop
+
+	// ThrottleOverflowReject fails the delivery of the excess messages with ErrorThrottled
+	ThrottleOverflowReject
+)
+
+// ThrottleConfig caps the rate of the messages reaching the processing function with a token bucket
+type ThrottleConfig struct {
+	// Rate is the number of messages per second, zero disables the throttling
+	Rate float64
+	// Burst is the number of messages processed at once after an idle period, one when not positive
+	Burst int
+	// Overflow defines how the messages exceeding the rate are handled
+	Overflow ThrottleOverflow
+}
+
 // MailboxConfig defines configuration options for an actor's mailbox
 type MailboxConfig struct {
 	// Capacity defines the maximum number of messages the mailbox can hold
@@ -53,6 +99,8 @@ type MailboxConfig struct {
 	Capacity int
 	// Policy defines how the mailbox handles pressure when reaching capacity
 	Policy BackpressurePolicy
+	// Throttle caps the rate of the messages reaching the processing function, disabled by default
+	Throttle ThrottleConfig
 }
 
 // Controllable is the interface for controllable actors.
@@ -82,6 +130,8 @@ type Controller interface {
 	Append(child ActorRef) error
 	// Crop a child actor
 	//
+	// The child is stopped; a child run by a dispatcher completes its stop on its next turn, without being waited for.
+	//
 	// Parameters:
 	//   - child (url.URL): The URL of the child actor to be cropped.
 	//
@@ -101,6 +151,31 @@ type Relationable interface {
 	GetParent() (ActorRef, bool)
 }
 
+// Watchable is the interface for actors notifying their termination.
+type Watchable interface {
+	// Watch registers a watcher to be notified with a Terminated message when the actor stops.
+	//
+	// Watching an actor which is already stopped notifies the watcher immediately.
+	//
+	// Parameters:
+	//   - watcher (ActorRef): The actor to be notified.
+	//
+	// Returns:
+	//   - (error): An error if the watch fails, otherwise nil.
+	Watch(watcher ActorRef) error
+	// Unwatch removes a watcher.
+	//
+	// Parameters:
+	//   - watcher (ActorRef): The actor to be removed from the watchers.
+	Unwatch(watcher ActorRef)
+}
+
+// Terminated is the message delivered to the watchers of an actor when the actor stops.
+type Terminated struct {
+	// Address of the stopped actor
+	Address url.URL
+}
+
 // ActorRef is the interface for the actor reference.
 type ActorRef interface {
 	common.Addressable
@@ -108,6 +183,7 @@ type ActorRef interface {
 	Controllable
 	Controller
 	Relationable
+	Watchable
 }
 
 // Actor is part of the actor model framework underlying lang-actor.
@@ -123,6 +199,11 @@ type Actor[T any] interface {
 	State() T
 	// Children returns the children of the actor.
 	Children() []ActorRef
+	// Logger of the actor
+	//
+	// Returns:
+	//   - (*slog.Logger): The logger scoped with the actor address.
+	Logger() *slog.Logger
 }
 
 // Message is the interface for messages sent to actors.
@@ -135,8 +216,13 @@ type Message interface {
 	// Sender returns the URL of the sender.
 	//
 	// Returns:
-	//   - (url.URL): The URL of the sender.
+	//   - (url.URL): The URL of the sender, the zero URL when the message was delivered without sender.
 	Sender() url.URL
+	// Metadata returns the out-of-band data delivered along with the payload.
+	//
+	// Returns:
+	//   - (common.Metadata): The metadata of the message, e.g. the trace context.
+	Metadata() common.Metadata
 }
 
 // ProcessingFn defines a generic function type for processing messages within an actor system.
@@ -0,0 +1,67 @@
+package framework
+
+import "time"
+
+// Clock is the interface for the source of time used by the framework.
+type Clock interface {
+	// Now returns the current time.
+	//
+	// Returns:
+	//   - (time.Time): The current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	//
+	// Parameters:
+	//   - t (time.Time): The reference time.
+	//
+	// Returns:
+	//   - (time.Duration): The elapsed time.
+	Since(t time.Time) time.Duration
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	//
+	// Parameters:
+	//   - d (time.Duration): The duration to wait.
+	//
+	// Returns:
+	//   - (<-chan time.Time): The channel receiving the time once elapsed.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a new timer sending the current time on its channel after the duration.
+	//
+	// Parameters:
+	//   - d (time.Duration): The duration to wait.
+	//
+	// Returns:
+	//   - (Timer): The created timer.
+	NewTimer(d time.Duration) Timer
+	// AfterFunc waits for the duration to elapse and then calls fn in its own goroutine.
+	//
+	// Parameters:
+	//   - d (time.Duration): The duration to wait.
+	//   - fn (func()): The function to be called.
+	//
+	// Returns:
+	//   - (Timer): The timer, which can be used to cancel the call.
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// Timer is the interface for a single event created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered, nil for AfterFunc timers.
+	//
+	// Returns:
+	//   - (<-chan time.Time): The channel of the timer.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing.
+	//
+	// Returns:
+	//   - (bool): False if the timer already expired or has been stopped, otherwise true.
+	Stop() bool
+	// Reset changes the timer to expire after the duration.
+	//
+	// Parameters:
+	//   - d (time.Duration): The new duration.
+	//
+	// Returns:
+	//   - (bool): True if the timer had been active, otherwise false.
+	Reset(d time.Duration) bool
+}
@@ -0,0 +1,36 @@
+package framework
+
+import "github.com/morphy76/lang-actor/pkg/common"
+
+// Dispatchable is the interface for the actors run by a Dispatcher.
+type Dispatchable interface {
+	common.Addressable
+	// Run processes up to throughput pending messages.
+	//
+	// Run is never called concurrently for the same Dispatchable.
+	//
+	// Parameters:
+	//   - throughput (int): The maximum number of messages processed in this turn.
+	//
+	// Returns:
+	//   - (bool): True when the dispatchable needs another turn, which must be given without a new Schedule call.
+	Run(throughput int) bool
+}
+
+// Dispatcher is the interface for the schedulers of actors with pending messages.
+//
+// Actors created without a dispatcher are pinned to a dedicated goroutine.
+type Dispatcher interface {
+	// Schedule requests a turn for the dispatchable.
+	//
+	// Parameters:
+	//   - dispatchable (Dispatchable): The actor with pending messages.
+	Schedule(dispatchable Dispatchable)
+}
+
+// PoolDispatcher is the interface for the dispatchers running the actors on a bounded set of shared goroutines.
+type PoolDispatcher interface {
+	Dispatcher
+	// Shutdown stops the workers once the pending turns are completed, later turns run on the scheduling goroutine.
+	Shutdown()
+}
@@ -0,0 +1,29 @@
+package framework
+
+import (
+	"errors"
+	"net/url"
+)
+
+// ErrorUnknownGrainKind is returned when a grain of a kind not registered in the actor system is requested.
+var ErrorUnknownGrainKind = errors.New("unknown grain kind")
+
+// ErrorGrainKindRegistered is returned when a grain kind is registered twice in the same actor system.
+var ErrorGrainKindRegistered = errors.New("grain kind already registered")
+
+// GrainActivator is the interface for the creators of the grain references of a kind.
+//
+// Grains are virtual actors: their reference always works, the actor is activated by the first message
+// and passivated when idle.
+type GrainActivator interface {
+	// Activate creates the reference of the grain with the given address.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the grain.
+	//   - system (ActorSystem): The actor system of the grain.
+	//
+	// Returns:
+	//   - (ActorRef): The reference of the grain.
+	//   - (error): An error if the reference cannot be created, otherwise nil.
+	Activate(address url.URL, system ActorSystem) (ActorRef, error)
+}
@@ -0,0 +1,36 @@
+package framework
+
+import "errors"
+
+// ErrorInterceptedState is returned when an interceptor returns a state of another type than the actor state.
+var ErrorInterceptedState = errors.New("intercepted state of unexpected type")
+
+// ErrorProcessingPanic is returned by the recover interceptor when the processing panics.
+var ErrorProcessingPanic = errors.New("message processing panicked")
+
+// Next continues the processing of a message with the next interceptor, the processing function last.
+//
+// Parameters:
+//   - msg: The message to be processed, possibly replaced by the calling interceptor.
+//
+// Returns:
+//   - any: The updated state of the actor.
+//   - error: An error if the processing fails, otherwise nil.
+type Next func(msg Message) (any, error)
+
+// Interceptor wraps the processing of every message of an actor, e.g. to log, measure, recover or authorize.
+//
+// The interceptors of the actor system run before the interceptors of the actor, in the order they are given;
+// an interceptor short-circuits the processing by returning without calling next, and observes the error of the
+// processing before the actor failure handling.
+//
+// Parameters:
+//   - msg: The message being processed.
+//   - self: The actor processing the message.
+//   - state: The state of the actor before the processing.
+//   - next: The continuation of the processing.
+//
+// Returns:
+//   - any: The updated state of the actor, of the type of the actor state or nil for its zero value.
+//   - error: An error if the processing fails, otherwise nil.
+type Interceptor func(msg Message, self ActorRef, state any, next Next) (any, error)
@@ -0,0 +1,113 @@
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// String returns the name of the actor status.
+func (s ActorStatus) String() string {
+	switch s {
+	case ActorStatusIdle:
+		return "idle"
+	case ActorStatusRunning:
+		return "running"
+	default:
+		return "unknown"
+	}
+}
+
+// ActorStats is a snapshot of the runtime figures of an actor.
+type ActorStats struct {
+	// MailboxDepth is the number of messages waiting in the mailbox
+	MailboxDepth int
+	// MailboxCapacity is the capacity of the mailbox, zero when unbounded
+	MailboxCapacity int
+	// Policy is the backpressure policy of the mailbox
+	Policy BackpressurePolicy
+	// Processed is the number of messages processed
+	Processed uint64
+	// LastError is the last processing error, nil when none
+	LastError error
+	// Uptime is the time elapsed since the actor was created, measured by the actor clock
+	Uptime time.Duration
+}
+
+// Inspectable is implemented by the actors reporting their runtime figures.
+type Inspectable interface {
+	// Stats returns a snapshot of the runtime figures of the actor.
+	//
+	// Returns:
+	//   - (ActorStats): The runtime figures of the actor.
+	Stats() ActorStats
+}
+
+// ActorInfo describes an actor and its children, as found when walking the actor tree.
+type ActorInfo struct {
+	// Address of the actor
+	Address string `json:"address"`
+	// Status of the actor
+	Status string `json:"status"`
+	// MailboxDepth is the number of messages waiting in the mailbox
+	MailboxDepth int `json:"mailbox_depth"`
+	// MailboxCapacity is the capacity of the mailbox, zero when unbounded
+	MailboxCapacity int `json:"mailbox_capacity"`
+	// Policy is the backpressure policy of the mailbox
+	Policy string `json:"policy,omitempty"`
+	// Processed is the number of messages processed
+	Processed uint64 `json:"processed"`
+	// LastError is the message of the last processing error
+	LastError string `json:"last_error,omitempty"`
+	// Uptime is the time elapsed since the actor was created
+	Uptime time.Duration `json:"uptime_ns"`
+	// Children are the children of the actor
+	Children []ActorInfo `json:"children,omitempty"`
+}
+
+// ActorTree is a snapshot of actor hierarchies, from their roots.
+type ActorTree []ActorInfo
+
+// JSON renders the tree as indented JSON.
+//
+// Returns:
+//   - ([]byte): The JSON document, an array of the roots.
+//   - (error): An error if the tree cannot be encoded.
+func (t ActorTree) JSON() ([]byte, error) {
+	if t == nil {
+		t = ActorTree{}
+	}
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// String renders the tree as indented text, one actor per line.
+//
+// Returns:
+//   - (string): The rendered tree.
+func (t ActorTree) String() string {
+	builder := &strings.Builder{}
+	for _, root := range t {
+		root.render(builder, 0)
+	}
+	return builder.String()
+}
+
+func (i ActorInfo) render(builder *strings.Builder, depth int) {
+	capacity := "unbounded"
+	if i.MailboxCapacity > 0 {
+		capacity = fmt.Sprint(i.MailboxCapacity)
+	}
+	fmt.Fprintf(builder, "%s%s [%s] mailbox=%d/%s", strings.Repeat("  ", depth), i.Address, i.Status, i.MailboxDepth, capacity)
+	if i.Policy != "" {
+		fmt.Fprintf(builder, " policy=%s", i.Policy)
+	}
+	fmt.Fprintf(builder, " processed=%d uptime=%s", i.Processed, i.Uptime.Round(time.Millisecond))
+	if i.LastError != "" {
+		fmt.Fprintf(builder, " last_error=%q", i.LastError)
+	}
+	builder.WriteString("\n")
+	for _, child := range i.Children {
+		child.render(builder, depth+1)
+	}
+}
@@ -0,0 +1,57 @@
+package framework
+
+import (
+	"net/url"
+	"time"
+)
+
+// LifecycleEventKind is the kind of change of the lifecycle of an actor.
+type LifecycleEventKind int
+
+const (
+	// LifecycleStarted is published when an actor is created
+	LifecycleStarted LifecycleEventKind = iota
+	// LifecycleStopped is published when an actor terminates
+	LifecycleStopped
+	// LifecycleRestarted is published when an actor state is reset to its initial state
+	LifecycleRestarted
+	// LifecycleFailed is published when an actor fails to process a message
+	LifecycleFailed
+)
+
+// String returns the name of the lifecycle event kind.
+func (k LifecycleEventKind) String() string {
+	switch k {
+	case LifecycleStarted:
+		return "started"
+	case LifecycleStopped:
+		return "stopped"
+	case LifecycleRestarted:
+		return "restarted"
+	case LifecycleFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// LifecycleEvent is a change of the lifecycle of an actor of an actor system.
+type LifecycleEvent struct {
+	// Kind of the change
+	Kind LifecycleEventKind
+	// Address of the actor
+	Address url.URL
+	// Time of the change, read from the actor clock
+	Time time.Time
+	// Err is the processing error of the failures
+	Err error
+}
+
+// Restartable is implemented by the actors whose state can be reset to the initial state.
+type Restartable interface {
+	// Restart resets the actor state to its initial state, once the messages already in the mailbox are processed.
+	//
+	// Returns:
+	//   - (error): An error if the actor is not running.
+	Restart() error
+}
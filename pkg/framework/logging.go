@@ -0,0 +1,17 @@
+package framework
+
+// Attribute keys used by the framework diagnostics.
+const (
+	// LogAttrSystem is the name of the actor system
+	LogAttrSystem = "system"
+	// LogAttrActorAddress is the address of the actor
+	LogAttrActorAddress = "actor.address"
+	// LogAttrMessageType is the Go type of the message payload
+	LogAttrMessageType = "message.type"
+	// LogAttrGraph is the address of the graph
+	LogAttrGraph = "graph"
+	// LogAttrGraphNode is the address of the graph node
+	LogAttrGraphNode = "graph.node"
+	// LogAttrError is the error being reported
+	LogAttrError = "error"
+)
@@ -0,0 +1,47 @@
+package framework
+
+import (
+	"net/url"
+	"time"
+)
+
+// Metrics is the interface for the instrumentation of actors and their mailboxes.
+type Metrics interface {
+	// ActorStarted records that an actor started consuming its mailbox.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	ActorStarted(address url.URL)
+	// ActorStopped records that an actor stopped consuming its mailbox.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	ActorStopped(address url.URL)
+	// MessageEnqueued records a message accepted by the mailbox.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//   - policy (BackpressurePolicy): The backpressure policy of the mailbox.
+	//   - depth (int): The number of messages waiting in the mailbox after the enqueue.
+	MessageEnqueued(address url.URL, policy BackpressurePolicy, depth int)
+	// MessageDropped records a message discarded by the mailbox.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//   - policy (BackpressurePolicy): The backpressure policy of the mailbox.
+	MessageDropped(address url.URL, policy BackpressurePolicy)
+	// MessageRejected records a message refused by the mailbox with an error to the sender.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//   - policy (BackpressurePolicy): The backpressure policy of the mailbox.
+	MessageRejected(address url.URL, policy BackpressurePolicy)
+	// MessageProcessed records a message handled by the processing function.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//   - elapsed (time.Duration): The time spent in the processing function.
+	//   - depth (int): The number of messages waiting in the mailbox after the processing.
+	//   - err (error): The error returned by the processing function, if any.
+	MessageProcessed(address url.URL, elapsed time.Duration, depth int, err error)
+}
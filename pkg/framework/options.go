@@ -0,0 +1,52 @@
+package framework
+
+import (
+	"log/slog"
+
+	"github.com/morphy76/lang-actor/pkg/routing"
+	"github.com/morphy76/lang-actor/pkg/tracing"
+)
+
+// ActorConfig collects the optional settings used to create an actor.
+type ActorConfig struct {
+	// Mailbox defines the configuration of the actor's mailbox
+	Mailbox MailboxConfig
+	// Metrics records the actor and mailbox instrumentation, nil disables it
+	Metrics Metrics
+	// Tracer creates a span around each message processing, nil only propagates the trace context
+	Tracer tracing.Tracer
+	// Logger receives the framework diagnostics, slog.Default() when nil
+	Logger *slog.Logger
+	// System the actor belongs to, its options are applied before the actor own options
+	System ActorSystem
+	// Clock is the source of time of the actor, the wall clock when nil
+	Clock Clock
+	// Dispatcher schedules the actor, nil pins the actor to a dedicated goroutine
+	Dispatcher Dispatcher
+	// AddressBook registers the actor, and its children, when created and unregisters it when stopped, nil disables the registration
+	AddressBook routing.AddressBook
+	// Interceptors wrap the processing of every message, outermost first
+	Interceptors []Interceptor
+}
+
+// ActorOption is the interface for the options applied when an actor is created.
+type ActorOption interface {
+	// Apply the option to the actor configuration
+	//
+	// Parameters:
+	//   - config (*ActorConfig): The configuration to be updated.
+	Apply(config *ActorConfig)
+}
+
+// ActorOptionFn adapts a function to the ActorOption interface.
+type ActorOptionFn func(config *ActorConfig)
+
+// Apply calls the underlying function with the given configuration.
+func (fn ActorOptionFn) Apply(config *ActorConfig) {
+	fn(config)
+}
+
+// Apply sets the mailbox configuration, MailboxConfig can then be used as an ActorOption.
+func (m MailboxConfig) Apply(config *ActorConfig) {
+	config.Mailbox = m
+}
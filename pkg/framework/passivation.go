@@ -0,0 +1,78 @@
+package framework
+
+import (
+	"net/url"
+	"time"
+)
+
+// StateStore is the interface for the storage of the state of passivated actors.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+type StateStore[T any] interface {
+	// Save stores the state of the actor.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//   - state (T): The state to be stored.
+	//
+	// Returns:
+	//   - (error): An error if the state cannot be stored, otherwise nil.
+	Save(address url.URL, state T) error
+	// Load retrieves the stored state of the actor.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//
+	// Returns:
+	//   - (T): The stored state.
+	//   - (bool): A boolean indicating whether a state was stored.
+	//   - (error): An error if the state cannot be retrieved, otherwise nil.
+	Load(address url.URL) (T, bool, error)
+}
+
+// ActorFactory creates the processing function and the initial state of a new incarnation of a passivating actor.
+//
+// The initial state is replaced by the stored one, when available.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+type ActorFactory[T any] func(address url.URL) (ProcessingFn[T], T, error)
+
+// PassivationConfig defines when and how a passivating actor is dropped from memory.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+type PassivationConfig[T any] struct {
+	// IdleTimeout is the time without messages after which the incarnation is stopped, zero never passivates
+	IdleTimeout time.Duration
+	// Store keeps the state between the incarnations, nil restarts every incarnation from the factory state
+	Store StateStore[T]
+}
+
+// PassivatingActor is the interface for the actor references outliving the incarnations of their actor.
+//
+// The incarnation stops after the idle timeout and a new one is created by the factory, with the stored state,
+// when the next message is delivered; the reference can stay registered under its address meanwhile.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+type PassivatingActor[T any] interface {
+	ActorRef
+	// Active returns whether an incarnation of the actor is in memory.
+	//
+	// Returns:
+	//   - (bool): True when an incarnation is running.
+	Active() bool
+	// Passivate stops the current incarnation, storing its state.
+	//
+	// Returns:
+	//   - (error): An error if the state cannot be stored, otherwise nil.
+	Passivate() error
+	// State returns the state of the running incarnation, or the stored one while passivated.
+	//
+	// Returns:
+	//   - (T): The state of the actor.
+	//   - (bool): A boolean indicating whether a state is available.
+	State() (T, bool)
+}
@@ -0,0 +1,12 @@
+package framework
+
+// Piped is the message delivered back to an actor with the outcome of the work it piped to itself.
+//
+// Type Parameters:
+//   - R: The type of the result of the work.
+type Piped[R any] struct {
+	// Value is the result of the work, the zero value when it failed
+	Value R
+	// Err is the error of the work, nil when it succeeded
+	Err error
+}
@@ -0,0 +1,43 @@
+package framework
+
+import (
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/common"
+)
+
+// Cancellable is the interface for scheduled deliveries.
+type Cancellable interface {
+	// Cancel stops the scheduled delivery.
+	//
+	// Returns:
+	//   - (bool): False if the delivery already happened or has been cancelled, otherwise true.
+	Cancel() bool
+}
+
+// Scheduler is the interface to deliver messages in the future.
+type Scheduler interface {
+	// ScheduleOnce delivers a message after the given delay.
+	//
+	// Parameters:
+	//   - delay (time.Duration): The delay before the delivery.
+	//   - msg (any): The message to be delivered.
+	//   - destination (common.Transport): The destination of the message.
+	//   - from (common.Addressable): The sender of the message.
+	//
+	// Returns:
+	//   - (Cancellable): The handle to cancel the delivery.
+	ScheduleOnce(delay time.Duration, msg any, destination common.Transport, from common.Addressable) Cancellable
+	// ScheduleRepeatedly delivers a message after the initial delay and then at every interval, until cancelled.
+	//
+	// Parameters:
+	//   - initialDelay (time.Duration): The delay before the first delivery.
+	//   - interval (time.Duration): The delay between the following deliveries.
+	//   - msg (any): The message to be delivered.
+	//   - destination (common.Transport): The destination of the message.
+	//   - from (common.Addressable): The sender of the message.
+	//
+	// Returns:
+	//   - (Cancellable): The handle to cancel the deliveries.
+	ScheduleRepeatedly(initialDelay time.Duration, interval time.Duration, msg any, destination common.Transport, from common.Addressable) Cancellable
+}
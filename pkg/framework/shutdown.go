@@ -0,0 +1,107 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// ErrorUnknownPhase is returned when a shutdown task is added to a phase which does not exist.
+var ErrorUnknownPhase = errors.New("unknown shutdown phase")
+
+// ErrorShutdownStarted is returned when a shutdown task is added once the shutdown started.
+var ErrorShutdownStarted = errors.New("shutdown already started")
+
+// ErrorShutdownTimeout is reported for the shutdown tasks not completing within their timeout.
+var ErrorShutdownTimeout = errors.New("shutdown task timed out")
+
+// Names of the default shutdown phases, in order.
+const (
+	// PhaseStopInput stops accepting input, e.g. HTTP listeners and consumers
+	PhaseStopInput = "stop-input"
+	// PhaseDrainGraphs lets the running graphs complete
+	PhaseDrainGraphs = "drain-graphs"
+	// PhaseStopWorkers stops the actors
+	PhaseStopWorkers = "stop-workers"
+	// PhaseFlushPersistence flushes the state stores and the outboxes
+	PhaseFlushPersistence = "flush-persistence"
+	// PhaseCloseTransports closes the connections, the name services and the exporters
+	PhaseCloseTransports = "close-transports"
+)
+
+// ShutdownPhase is a step of a coordinated shutdown, its tasks run concurrently.
+type ShutdownPhase struct {
+	// Name of the phase
+	Name string
+	// Timeout is the default timeout of the tasks of the phase, the shutdown default when zero
+	Timeout time.Duration
+}
+
+// DefaultShutdownPhases are the phases of a coordinated shutdown when none are configured.
+var DefaultShutdownPhases = []ShutdownPhase{
+	{Name: PhaseStopInput},
+	{Name: PhaseDrainGraphs},
+	{Name: PhaseStopWorkers},
+	{Name: PhaseFlushPersistence},
+	{Name: PhaseCloseTransports},
+}
+
+// ShutdownTask is a unit of work of a shutdown phase.
+//
+// Parameters:
+//   - ctx: The context cancelled when the task times out.
+//
+// Returns:
+//   - error: An error if the task fails, otherwise nil.
+type ShutdownTask func(ctx context.Context) error
+
+// ShutdownConfig defines the phases of a coordinated shutdown.
+type ShutdownConfig struct {
+	// Phases in order of execution, DefaultShutdownPhases when empty
+	Phases []ShutdownPhase
+	// Timeout is the default timeout of the tasks, 10 seconds when not positive
+	Timeout time.Duration
+	// Options provide the clock measuring the timeouts and the logger
+	Options []ActorOption
+}
+
+// CoordinatedShutdown runs the shutdown tasks phase by phase, once.
+type CoordinatedShutdown interface {
+	// AddTask registers a task into a phase
+	//
+	// Parameters:
+	//   - phase (string): The name of the phase.
+	//   - name (string): The name of the task, for the diagnostics.
+	//   - timeout (time.Duration): The timeout of the task, the phase default when zero.
+	//   - task (ShutdownTask): The task.
+	//
+	// Returns:
+	//   - (error): ErrorUnknownPhase or ErrorShutdownStarted if the task cannot be added, otherwise nil.
+	AddTask(phase string, name string, timeout time.Duration, task ShutdownTask) error
+	// Run runs the phases in order, waiting for every task of a phase before the next one
+	//
+	// The failing and timed out tasks do not stop the shutdown; the later calls wait for the first one.
+	//
+	// Parameters:
+	//   - reason (string): The reason of the shutdown, for the diagnostics.
+	//
+	// Returns:
+	//   - (error): The errors of the failing and timed out tasks, nil when every task completed.
+	Run(reason string) error
+	// Done returns a channel closed when the shutdown completed
+	//
+	// Returns:
+	//   - (<-chan struct{}): The channel closed at the end of the last phase.
+	Done() <-chan struct{}
+	// NotifySignals runs the shutdown when the process receives one of the signals
+	//
+	// The signals are released once the first one is received, so that a second one terminates the process as by default.
+	//
+	// Parameters:
+	//   - signals (...os.Signal): The signals, SIGINT and SIGTERM when none are given.
+	//
+	// Returns:
+	//   - (func()): The function releasing the signals.
+	NotifySignals(signals ...os.Signal) func()
+}
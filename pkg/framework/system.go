@@ -0,0 +1,65 @@
+package framework
+
+import (
+	"log/slog"
+
+	"github.com/morphy76/lang-actor/pkg/routing"
+)
+
+// ActorSystem groups actors sharing the same settings.
+type ActorSystem interface {
+	// Name of the actor system
+	//
+	// Returns:
+	//   - (string): The name of the actor system.
+	Name() string
+	// Logger of the actor system
+	//
+	// Returns:
+	//   - (*slog.Logger): The logger scoped with the system name.
+	Logger() *slog.Logger
+	// Options applied to every actor of the system before the actor own options
+	//
+	// Returns:
+	//   - ([]ActorOption): The options of the actor system.
+	Options() []ActorOption
+	// AddressBook of the actor system
+	//
+	// Returns:
+	//   - (routing.AddressBook): The address book shared by the actors of the system, e.g. the grains.
+	AddressBook() routing.AddressBook
+	// RegisterKind registers the activator of the grains of a kind
+	//
+	// Parameters:
+	//   - kind (string): The kind of the grains.
+	//   - activator (GrainActivator): The activator creating the grain references.
+	//
+	// Returns:
+	//   - (error): An error if the kind is already registered, otherwise nil.
+	RegisterKind(kind string, activator GrainActivator) error
+	// Kind returns the activator of the grains of a kind
+	//
+	// Parameters:
+	//   - kind (string): The kind of the grains.
+	//
+	// Returns:
+	//   - (GrainActivator): The activator of the kind.
+	//   - (bool): A boolean indicating whether the kind is registered.
+	Kind(kind string) (GrainActivator, bool)
+	// Roots returns the running actors of the system without a parent
+	//
+	// Returns:
+	//   - ([]ActorRef): The roots of the actor hierarchies of the system, sorted by address.
+	Roots() []ActorRef
+	// Subscribe streams the lifecycle events of the actors of the system
+	//
+	// The events are dropped for the subscribers not keeping up, so that the actors never wait.
+	//
+	// Parameters:
+	//   - buffer (int): The number of events buffered for the subscriber.
+	//
+	// Returns:
+	//   - (<-chan LifecycleEvent): The stream of events.
+	//   - (func()): The function ending the subscription and closing the stream.
+	Subscribe(buffer int) (<-chan LifecycleEvent, func())
+}
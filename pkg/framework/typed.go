@@ -0,0 +1,89 @@
+package framework
+
+import (
+	"errors"
+
+	"github.com/morphy76/lang-actor/pkg/common"
+)
+
+// ErrorUnexpectedMessage is returned when a typed actor receives a payload of another type.
+var ErrorUnexpectedMessage = errors.New("unexpected message type")
+
+// TypedProcessingFn defines the processing function of the actors accepting a single message type.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//   - M: The type of the messages accepted by the actor.
+//
+// Parameters:
+//   - msg: The payload of the message, already of type M.
+//   - self: The actor instance that is processing the message.
+//
+// Returns:
+//   - T: The updated state of the actor after processing the message.
+//   - error: An error if the processing fails, otherwise nil.
+type TypedProcessingFn[T any, M any] func(
+	msg M,
+	self Actor[T],
+) (T, error)
+
+// TypedRef is a reference to an actor accepting the messages of a single type, checked at compile time.
+//
+// Type Parameters:
+//   - M: The type of the messages accepted by the actor.
+type TypedRef[M any] interface {
+	common.Addressable
+	// Tell delivers a message to the actor without a sender
+	//
+	// Parameters:
+	//   - msg (M): The message to be delivered.
+	//
+	// Returns:
+	//   - (error): An error if the delivery fails, otherwise nil.
+	Tell(msg M) error
+	// TellFrom delivers a message to the actor on behalf of a sender
+	//
+	// Parameters:
+	//   - msg (M): The message to be delivered.
+	//   - from (common.Addressable): The sender of the message.
+	//
+	// Returns:
+	//   - (error): An error if the delivery fails, otherwise nil.
+	TellFrom(msg M, from common.Addressable) error
+	// Untyped returns the underlying reference, accepting any message
+	//
+	// Returns:
+	//   - (ActorRef): The untyped reference to the same actor.
+	Untyped() ActorRef
+}
+
+// TypedActor is an actor accepting the messages of a single type.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//   - M: The type of the messages accepted by the actor.
+type TypedActor[T any, M any] interface {
+	Actor[T]
+	// Tell delivers a message to the actor without a sender
+	//
+	// Parameters:
+	//   - msg (M): The message to be delivered.
+	//
+	// Returns:
+	//   - (error): An error if the delivery fails, otherwise nil.
+	Tell(msg M) error
+	// TellFrom delivers a message to the actor on behalf of a sender
+	//
+	// Parameters:
+	//   - msg (M): The message to be delivered.
+	//   - from (common.Addressable): The sender of the message.
+	//
+	// Returns:
+	//   - (error): An error if the delivery fails, otherwise nil.
+	TellFrom(msg M, from common.Addressable) error
+	// Ref returns the typed reference to the actor, to be handed to the senders
+	//
+	// Returns:
+	//   - (TypedRef[M]): The typed reference.
+	Ref() TypedRef[M]
+}
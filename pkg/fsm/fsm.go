@@ -0,0 +1,198 @@
+package fsm
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// ErrorUnknownState is returned when a machine refers to a state without handler.
+var ErrorUnknownState = errors.New("unknown FSM state")
+
+// State is the state of an FSM actor: the name of the current state and the data carried across the states.
+//
+// Type Parameters:
+//   - D: The type of the data.
+type State[D any] struct {
+	// Name of the current state, for introspection and metrics
+	Name string
+	// Data carried by the machine
+	Data D
+	// Generation counts the messages processed by the machine, identifying the pending state timeout
+	Generation uint64
+}
+
+// StateTimeout is the message received by a state when no other message arrived within its timeout.
+type StateTimeout struct {
+	// State which timed out
+	State string
+	// Generation of the state when the timeout was scheduled, stale timeouts are discarded
+	Generation uint64
+}
+
+// Transition is the outcome of a state handler, built with Goto, Stay or Unhandled.
+//
+// Type Parameters:
+//   - D: The type of the data.
+type Transition[D any] struct {
+	next      string
+	data      D
+	unhandled bool
+}
+
+// Next returns the target state, empty when staying in the current state.
+//
+// Returns:
+//   - (string): The name of the target state.
+func (t Transition[D]) Next() string {
+	return t.next
+}
+
+// Data returns the data of the machine after the transition.
+//
+// Returns:
+//   - (D): The data of the machine.
+func (t Transition[D]) Data() D {
+	return t.data
+}
+
+// IsUnhandled returns whether the handler did not handle the message.
+//
+// Returns:
+//   - (bool): True when the message was not handled.
+func (t Transition[D]) IsUnhandled() bool {
+	return t.unhandled
+}
+
+// Goto moves the machine to the given state.
+//
+// Type Parameters:
+//   - D: The type of the data.
+//
+// Parameters:
+//   - state (string): The target state.
+//   - data (D): The data of the machine in the target state.
+//
+// Returns:
+//   - (Transition[D]): The transition.
+func Goto[D any](state string, data D) Transition[D] {
+	return Transition[D]{next: state, data: data}
+}
+
+// Stay keeps the machine in the current state.
+//
+// Type Parameters:
+//   - D: The type of the data.
+//
+// Parameters:
+//   - data (D): The data of the machine.
+//
+// Returns:
+//   - (Transition[D]): The transition.
+func Stay[D any](data D) Transition[D] {
+	return Transition[D]{data: data}
+}
+
+// Unhandled hands the message over to the unhandled fallback of the machine.
+//
+// Type Parameters:
+//   - D: The type of the data.
+//
+// Returns:
+//   - (Transition[D]): The transition.
+func Unhandled[D any]() Transition[D] {
+	return Transition[D]{unhandled: true}
+}
+
+// Handler processes the messages received in a state.
+//
+// Type Parameters:
+//   - D: The type of the data.
+type Handler[D any] func(msg framework.Message, data D, self framework.Actor[State[D]]) (Transition[D], error)
+
+// TransitionHook is called when the machine moves from a state to another.
+//
+// Type Parameters:
+//   - D: The type of the data.
+type TransitionHook[D any] func(from string, to string, data D)
+
+// Builder is the interface to describe a finite state machine.
+//
+// Type Parameters:
+//   - D: The type of the data.
+type Builder[D any] interface {
+	// When sets the handler of a state.
+	//
+	// Parameters:
+	//   - state (string): The name of the state.
+	//   - handler (Handler[D]): The handler of the messages received in the state.
+	//
+	// Returns:
+	//   - (Builder[D]): The builder.
+	When(state string, handler Handler[D]) Builder[D]
+	// Timeout sends a StateTimeout to the machine when no message arrives within the duration while in the state.
+	//
+	// Parameters:
+	//   - state (string): The name of the state.
+	//   - timeout (time.Duration): The maximum time without messages.
+	//
+	// Returns:
+	//   - (Builder[D]): The builder.
+	Timeout(state string, timeout time.Duration) Builder[D]
+	// OnTransition adds a hook called on every change of state.
+	//
+	// Parameters:
+	//   - hook (TransitionHook[D]): The hook.
+	//
+	// Returns:
+	//   - (Builder[D]): The builder.
+	OnTransition(hook TransitionHook[D]) Builder[D]
+	// WhenUnhandled sets the fallback of the messages not handled by the current state, which are logged otherwise.
+	//
+	// Parameters:
+	//   - handler (Handler[D]): The fallback handler.
+	//
+	// Returns:
+	//   - (Builder[D]): The builder.
+	WhenUnhandled(handler Handler[D]) Builder[D]
+	// Build validates the description of the machine.
+	//
+	// Returns:
+	//   - (Machine[D]): The machine.
+	//   - (error): An error if the initial state has no handler.
+	Build() (Machine[D], error)
+}
+
+// Machine is the interface of a validated finite state machine.
+//
+// Type Parameters:
+//   - D: The type of the data.
+type Machine[D any] interface {
+	// InitialState returns the state of a new FSM actor.
+	//
+	// Returns:
+	//   - (State[D]): The initial state.
+	InitialState() State[D]
+	// ProcessingFn returns the processing function of a new FSM actor.
+	//
+	// The timeout of the initial state starts with the first message, use Spawn to start it with the actor.
+	//
+	// Parameters:
+	//   - options (...framework.ActorOption): The options of the actor, providing the clock of the state timeouts.
+	//
+	// Returns:
+	//   - (framework.ProcessingFn[State[D]]): The processing function.
+	ProcessingFn(options ...framework.ActorOption) framework.ProcessingFn[State[D]]
+	// Spawn creates a new FSM actor in the initial state.
+	//
+	// Parameters:
+	//   - address (url.URL): The address of the actor.
+	//   - options (...framework.ActorOption): Optional settings of the actor, e.g. the clock of the state timeouts.
+	//
+	// Returns:
+	//   - (framework.Actor[State[D]]): The created actor.
+	//   - (error): An error if the actor could not be created.
+	Spawn(address url.URL, options ...framework.ActorOption) (framework.Actor[State[D]], error)
+}
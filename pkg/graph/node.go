@@ -10,6 +10,9 @@ import (
 // ErrorInvalidRouting is returned when a routing is invalid.
 var ErrorInvalidRouting = errors.New("invalid routing")
 
+// ErrorNodeTimeout is returned when a node does not produce its outcome within the configured timeout.
+var ErrorNodeTimeout = errors.New("node timeout")
+
 // Routable represents a node that can have routes to other nodes.
 type Routable interface {
 	// SetResolver sets the resolver for the node.
@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+var staticNodeTimeoutAssertion GraphOption = (*NodeTimeout)(nil)
+
+// Settings collects the optional settings of a graph.
+type Settings struct {
+	// NodeTimeout bounds the wait of a node for the outcome of its task, zero waits forever
+	NodeTimeout time.Duration
+}
+
+// GraphOption is the interface for the options configuring the graph itself, besides the actors of its nodes.
+type GraphOption interface {
+	framework.ActorOption
+	// ApplyGraph applies the option to the graph settings
+	//
+	// Parameters:
+	//   - settings (*Settings): The settings to be updated.
+	ApplyGraph(settings *Settings)
+}
+
+// NodeTimeout bounds the wait of every node for the outcome of its task, measured with the clock of the node actors.
+type NodeTimeout time.Duration
+
+// Apply leaves the actor configuration untouched.
+func (n NodeTimeout) Apply(config *framework.ActorConfig) {}
+
+// ApplyGraph sets the node timeout of the graph.
+func (n NodeTimeout) ApplyGraph(settings *Settings) {
+	settings.NodeTimeout = time.Duration(n)
+}
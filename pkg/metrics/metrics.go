@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the processing latency histogram.
+var DefaultLatencyBuckets = []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry collects the actor and mailbox metrics and exposes them for scraping.
+type Registry interface {
+	framework.Metrics
+	// WriteText writes the collected metrics using the Prometheus text exposition format.
+	//
+	// Parameters:
+	//   - w (io.Writer): The writer to which the metrics are written.
+	//
+	// Returns:
+	//   - (error): An error if the writing fails, otherwise nil.
+	WriteText(w io.Writer) error
+	// Handler returns an HTTP handler serving the metrics using the Prometheus text exposition format.
+	//
+	// Returns:
+	//   - (http.Handler): The handler to be mounted on a scrape endpoint, e.g. /metrics.
+	Handler() http.Handler
+}
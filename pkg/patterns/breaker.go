@@ -0,0 +1,127 @@
+package patterns
+
+import (
+	"errors"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// ErrorCircuitOpen is returned by a circuit breaker refusing a call while open.
+var ErrorCircuitOpen = errors.New("circuit open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through, counting the consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen refuses every call until the reset timeout elapses
+	CircuitOpen
+	// CircuitHalfOpen lets a few trial calls through, closing on success and opening again on failure
+	CircuitHalfOpen
+)
+
+// String returns the name of the circuit state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig defines when a circuit breaker opens and when it tries again.
+type BreakerConfig struct {
+	// Name identifies the breaker in its transitions and diagnostics
+	Name string
+	// FailureThreshold is the number of consecutive failures opening the circuit, 5 when not positive
+	FailureThreshold int
+	// ResetTimeout is the time the circuit stays open before the trial calls, 30 seconds when not positive
+	ResetTimeout time.Duration
+	// HalfOpenCalls is the number of concurrent trial calls while half-open, 1 when not positive
+	HalfOpenCalls int
+	// IsFailure selects the errors counted as failures, every error when nil
+	IsFailure func(err error) bool
+	// Options provide the clock of the reset timeout and the logger
+	Options []framework.ActorOption
+}
+
+// CircuitTransition is a change of state of a circuit breaker.
+type CircuitTransition struct {
+	// Name of the breaker
+	Name string
+	// From is the state before the change
+	From CircuitState
+	// To is the state after the change
+	To CircuitState
+	// Time of the change, measured by the breaker clock
+	Time time.Time
+}
+
+// CircuitBreaker protects the callers of a failing dependency, failing fast while the dependency recovers.
+type CircuitBreaker interface {
+	// Execute calls a function through the breaker
+	//
+	// Parameters:
+	//   - fn (func() (any, error)): The function, e.g. a call to a remote service.
+	//
+	// Returns:
+	//   - (any): The result of the function.
+	//   - (error): The error of the function, or ErrorCircuitOpen when the call is refused.
+	Execute(fn func() (any, error)) (any, error)
+	// Ask sends a Request to an actor through the breaker and waits for its Reply
+	//
+	// The failed replies and the replies not arriving within the timeout count as failures.
+	//
+	// Parameters:
+	//   - payload (any): The payload of the request.
+	//   - responder (framework.ActorRef): The actor answering with Respond.
+	//   - timeout (time.Duration): The maximum time to wait for the reply, 5 seconds when not positive.
+	//
+	// Returns:
+	//   - (any): The payload of the reply.
+	//   - (error): The error of the reply, ErrorNoReply at the deadline, or ErrorCircuitOpen when the call is refused.
+	Ask(payload any, responder framework.ActorRef, timeout time.Duration) (any, error)
+	// State returns the current state of the breaker
+	//
+	// Returns:
+	//   - (CircuitState): The state of the breaker.
+	State() CircuitState
+	// Subscribe streams the changes of state of the breaker
+	//
+	// The transitions are dropped for the subscribers not keeping up, so that the callers never wait.
+	//
+	// Parameters:
+	//   - buffer (int): The number of transitions buffered for the subscriber.
+	//
+	// Returns:
+	//   - (<-chan CircuitTransition): The stream of transitions.
+	//   - (func()): The function ending the subscription and closing the stream.
+	Subscribe(buffer int) (<-chan CircuitTransition, func())
+}
+
+// Call calls a typed function through a circuit breaker.
+//
+// Type Parameters:
+//   - R: The type of the result of the function.
+//
+// Parameters:
+//   - breaker (CircuitBreaker): The circuit breaker.
+//   - fn (func() (R, error)): The function.
+//
+// Returns:
+//   - (R): The result of the function, the zero value when the call fails or is refused.
+//   - (error): The error of the function, or ErrorCircuitOpen when the call is refused.
+func Call[R any](breaker CircuitBreaker, fn func() (R, error)) (R, error) {
+	result, err := breaker.Execute(func() (any, error) {
+		return fn()
+	})
+	rv, _ := result.(R)
+	return rv, err
+}
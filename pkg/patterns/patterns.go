@@ -0,0 +1,77 @@
+package patterns
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/common"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// ErrorNoReply is reported for the responders which did not reply before the deadline.
+var ErrorNoReply = errors.New("no reply before the deadline")
+
+// Request carries a payload expecting a Reply.
+type Request struct {
+	// ID identifies the request among the replies
+	ID string
+	// Payload is the message for the responder
+	Payload any
+	// ReplyTo receives the Reply
+	ReplyTo common.Transport
+}
+
+// Reply is the answer to a Request.
+type Reply struct {
+	// ID of the answered request
+	ID string
+	// Payload of the reply, when successful
+	Payload any
+	// Err reports the failure of the responder
+	Err error
+}
+
+// Respond replies to a request.
+//
+// Parameters:
+//   - request (Request): The request to be answered.
+//   - from (common.Addressable): The responder.
+//   - payload (any): The payload of the reply, when successful.
+//   - err (error): The failure of the responder, nil when successful.
+//
+// Returns:
+//   - (error): An error if the reply cannot be delivered.
+func Respond(request Request, from common.Addressable, payload any, err error) error {
+	return request.ReplyTo.Deliver(Reply{ID: request.ID, Payload: payload, Err: err}, from)
+}
+
+// GatherConfig defines when a scatter-gather completes.
+type GatherConfig struct {
+	// Quorum is the number of successful replies completing the gathering, zero waits for every responder
+	Quorum int
+	// Timeout is the deadline of the gathering, zero waits without deadline
+	Timeout time.Duration
+	// Options provide the clock measuring the deadline
+	Options []framework.ActorOption
+}
+
+// Response is the successful reply of a responder.
+type Response struct {
+	// Responder is the address of the responder
+	Responder url.URL
+	// Payload is the payload of the reply
+	Payload any
+}
+
+// Gathered is the aggregated result of a scatter-gather, delivered to the requester.
+type Gathered struct {
+	// RequestID identifies the scattered request
+	RequestID string
+	// Responses are the successful replies, in order of arrival
+	Responses []Response
+	// Failures are the errors of the responders which failed, could not be reached or did not reply in time
+	Failures map[url.URL]error
+	// TimedOut reports whether the deadline completed the gathering
+	TimedOut bool
+}
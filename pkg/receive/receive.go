@@ -0,0 +1,134 @@
+package receive
+
+import (
+	"net/url"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// DeadLetter is the message forwarded by the dead letter sink for every unhandled message.
+type DeadLetter struct {
+	// Payload of the unhandled message
+	Payload any
+	// Sender of the unhandled message
+	Sender url.URL
+	// Recipient is the actor which did not handle the message
+	Recipient url.URL
+}
+
+// Sink receives the messages matched by no case of a behavior, when no unhandled handler is set.
+//
+// Parameters:
+//   - msg: The unhandled message.
+//   - self: The actor which did not handle the message.
+//
+// Returns:
+//   - error: An error failing the processing of the message, otherwise nil.
+type Sink func(msg framework.Message, self framework.ActorRef) error
+
+// Case is a clause of a behavior, handling the messages it matches.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+type Case[T any] struct {
+	matches func(msg framework.Message) bool
+	handler framework.ProcessingFn[T]
+}
+
+// Matches returns whether the case handles the message.
+//
+// Parameters:
+//   - msg (framework.Message): The message.
+//
+// Returns:
+//   - (bool): True when the case handles the message.
+func (c Case[T]) Matches(msg framework.Message) bool {
+	return c.matches != nil && c.matches(msg)
+}
+
+// Handle processes a message matched by the case.
+//
+// Parameters:
+//   - msg (framework.Message): The message.
+//   - self (framework.Actor[T]): The actor processing the message.
+//
+// Returns:
+//   - (T): The updated state of the actor.
+//   - (error): An error if the processing fails, otherwise nil.
+func (c Case[T]) Handle(msg framework.Message, self framework.Actor[T]) (T, error) {
+	return c.handler(msg, self)
+}
+
+// OnType handles the messages whose payload is of type M.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//   - M: The type of the handled payloads.
+//
+// Parameters:
+//   - handler (framework.TypedProcessingFn[T, M]): The handler receiving the payload already typed.
+//
+// Returns:
+//   - (Case[T]): The case.
+func OnType[T any, M any](handler framework.TypedProcessingFn[T, M]) Case[T] {
+	return Case[T]{
+		matches: func(msg framework.Message) bool {
+			_, ok := msg.Payload().(M)
+			return ok
+		},
+		handler: func(msg framework.Message, self framework.Actor[T]) (T, error) {
+			return handler(msg.Payload().(M), self)
+		},
+	}
+}
+
+// OnMatch handles the messages satisfying a predicate.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+//
+// Parameters:
+//   - predicate (func(framework.Message) bool): The predicate selecting the messages.
+//   - handler (framework.ProcessingFn[T]): The handler of the selected messages.
+//
+// Returns:
+//   - (Case[T]): The case.
+func OnMatch[T any](predicate func(msg framework.Message) bool, handler framework.ProcessingFn[T]) Case[T] {
+	return Case[T]{matches: predicate, handler: handler}
+}
+
+// Builder is the interface to describe a behavior dispatching the messages by type or predicate.
+//
+// Type Parameters:
+//   - T: The type of the actor state.
+type Builder[T any] interface {
+	// On adds cases to the behavior, the first matching case handles the message.
+	//
+	// Parameters:
+	//   - cases (...Case[T]): The cases, built with OnType or OnMatch.
+	//
+	// Returns:
+	//   - (Builder[T]): The builder.
+	On(cases ...Case[T]) Builder[T]
+	// OnUnhandled sets the handler of the messages matched by no case, replacing the sink.
+	//
+	// Parameters:
+	//   - handler (framework.ProcessingFn[T]): The handler of the unhandled messages.
+	//
+	// Returns:
+	//   - (Builder[T]): The builder.
+	OnUnhandled(handler framework.ProcessingFn[T]) Builder[T]
+	// WithSink sets the sink of the unhandled messages, the actor log when not set.
+	//
+	// Parameters:
+	//   - sink (Sink): The sink, e.g. the log or the dead letters.
+	//
+	// Returns:
+	//   - (Builder[T]): The builder.
+	WithSink(sink Sink) Builder[T]
+	// Build compiles the behavior.
+	//
+	// Returns:
+	//   - (framework.ProcessingFn[T]): The processing function of the behavior, keeping the state on unhandled messages.
+	Build() framework.ProcessingFn[T]
+}
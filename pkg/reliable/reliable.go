@@ -0,0 +1,112 @@
+package reliable
+
+import (
+	"errors"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/common"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// ErrorProducerClosed is returned when publishing through a closed producer.
+var ErrorProducerClosed = errors.New("producer closed")
+
+// Sequenced carries a payload published by a reliable producer, to be acknowledged by the consumer.
+type Sequenced struct {
+	// ProducerID identifies the producer, the sequence numbers are scoped by producer
+	ProducerID string
+	// Seq is the sequence number of the payload, starting from 1
+	Seq uint64
+	// Payload is the published message
+	Payload any
+	// Acked is the sequence number up to which every message was acknowledged, so the consumer does not expect them
+	Acked uint64
+	// AckTo receives the Ack of the consumer
+	AckTo common.Transport `json:"-"`
+}
+
+// Ack acknowledges the processing of a Sequenced message.
+type Ack struct {
+	// ProducerID identifies the producer of the acknowledged message
+	ProducerID string
+	// Seq is the sequence number of the acknowledged message
+	Seq uint64
+}
+
+// Outbox is the interface for the storage of the messages published and not acknowledged yet.
+//
+// A durable outbox lets a new producer with the same address redeliver the pending messages after a restart.
+type Outbox interface {
+	// Append stores a published message.
+	//
+	// Parameters:
+	//   - entry (Sequenced): The published message.
+	//
+	// Returns:
+	//   - (error): An error if the message cannot be stored, the message is not published then.
+	Append(entry Sequenced) error
+	// Remove deletes an acknowledged message.
+	//
+	// Parameters:
+	//   - producerID (string): The producer of the message.
+	//   - seq (uint64): The sequence number of the message.
+	//
+	// Returns:
+	//   - (error): An error if the message cannot be deleted.
+	Remove(producerID string, seq uint64) error
+	// Pending returns the messages not acknowledged yet, sorted by sequence number.
+	//
+	// Parameters:
+	//   - producerID (string): The producer of the messages.
+	//
+	// Returns:
+	//   - ([]Sequenced): The pending messages.
+	//   - (error): An error if the messages cannot be read.
+	Pending(producerID string) ([]Sequenced, error)
+	// LastSequence returns the highest sequence number ever appended for the producer.
+	//
+	// Parameters:
+	//   - producerID (string): The producer.
+	//
+	// Returns:
+	//   - (uint64): The highest sequence number, 0 when nothing was appended.
+	//   - (error): An error if the sequence number cannot be read.
+	LastSequence(producerID string) (uint64, error)
+}
+
+// ProducerConfig collects the settings of a reliable producer.
+type ProducerConfig struct {
+	// Outbox stores the pending messages, in memory when nil
+	Outbox Outbox
+	// InitialBackoff is the wait before the first redelivery, 1 second when zero
+	InitialBackoff time.Duration
+	// MaxBackoff caps the doubling wait between redeliveries, 1 minute when zero
+	MaxBackoff time.Duration
+	// Options provide the clock timing the redeliveries and the logger
+	Options []framework.ActorOption
+}
+
+// Producer is the interface for the senders delivering their messages at least once.
+//
+// Every message is delivered wrapped in a Sequenced envelope and redelivered with backoff until the consumer
+// acknowledges it; the producer accepts the Ack messages as a transport.
+type Producer interface {
+	common.Addressable
+	common.Transport
+	// Publish stores the payload in the outbox and delivers it to the consumer until acknowledged.
+	//
+	// Parameters:
+	//   - payload (any): The message to be delivered.
+	//
+	// Returns:
+	//   - (uint64): The sequence number of the message.
+	//   - (error): An error if the message cannot be stored.
+	Publish(payload any) (uint64, error)
+	// Pending returns the number of messages not acknowledged yet.
+	//
+	// Returns:
+	//   - (int): The number of pending messages.
+	Pending() int
+	// Close stops the redeliveries, the pending messages stay in the outbox.
+	Close()
+}
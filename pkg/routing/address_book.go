@@ -3,6 +3,7 @@ package routing
 import (
 	"errors"
 	"net/url"
+	"time"
 
 	"github.com/morphy76/lang-actor/pkg/common"
 )
@@ -13,6 +14,76 @@ var ErrorActorAlreadyRegistered = errors.New("actor already registered")
 // ErrorActorNotFound is returned when an actor is not found in the catalog.
 var ErrorActorNotFound = errors.New("actor not found")
 
+// ErrorInvalidPattern is returned when an actor selection pattern cannot be parsed.
+var ErrorInvalidPattern = errors.New("invalid actor selection pattern")
+
+// ErrorLeaseExpired is returned when renewing a lease which expired or was revoked.
+var ErrorLeaseExpired = errors.New("lease expired")
+
+// EventKind is the kind of change of an address book registration.
+type EventKind int
+
+const (
+	// EventAdded is emitted when an Addressable is registered
+	EventAdded EventKind = iota
+	// EventRemoved is emitted when an Addressable is unregistered or its lease expires
+	EventRemoved
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventAdded:
+		return "added"
+	case EventRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a change of the address book registrations.
+type Event struct {
+	// Kind of the change
+	Kind EventKind
+	// Address of the registration
+	Address url.URL
+	// Addressable registered or unregistered
+	Addressable common.Addressable
+}
+
+// Watcher streams the changes of the registrations matching a pattern.
+type Watcher interface {
+	// Events returns the stream of changes, closed when the watcher stops.
+	//
+	// Returns:
+	//   - (<-chan Event): The events in order of change, never dropped.
+	Events() <-chan Event
+	// Stop stops the watcher and closes its stream.
+	Stop()
+}
+
+// Lease keeps a registration alive while it is renewed within its time to live.
+type Lease interface {
+	// Renew extends the registration by its time to live.
+	//
+	// Returns:
+	//   - (error): ErrorLeaseExpired if the registration already expired or was revoked.
+	Renew() error
+	// Revoke unregisters the Addressable before the lease expires.
+	Revoke()
+}
+
+// Selection is a group of Addressables selected by pattern; delivering to the selection broadcasts to its members.
+type Selection interface {
+	common.Transport
+	// Members returns the selected Addressables.
+	//
+	// Returns:
+	//   - ([]common.Addressable): The Addressables matching the pattern when the selection was made.
+	Members() []common.Addressable
+}
+
 // Resolver is an interface for resolving addresses to framework.Addressable.
 type Resolver interface {
 	// Register registers the given URL with the provided Addressable.
@@ -23,6 +94,14 @@ type Resolver interface {
 	// Returns:
 	//   - (error): An error if the registration fails.
 	Register(addressable common.Addressable) error
+	// Unregister removes the registration of the given URL.
+	//
+	// Parameters:
+	//   - address (url.URL): The URL to unregister.
+	//
+	// Returns:
+	//   - (error): ErrorActorNotFound if the URL is not registered.
+	Unregister(address url.URL) error
 	// Resolve resolves the given URL to a framework.Addressable.
 	//
 	// Parameters:
@@ -41,11 +120,56 @@ type Resolver interface {
 	// Returns:
 	//   - ([]common.Addressable): A slice of Addressables matching the query.
 	Query(schema string, pathParts ...string) []common.Addressable
+	// Select selects the Addressables matching a pattern.
+	//
+	// The host and each path segment of the pattern are matched separately: "*" matches a single segment,
+	// "**" matches any number of segments, "?" and "[...]" follow path.Match. A pattern without scheme is
+	// relative to the given address: ".." is its parent, "../sibling" a sibling and "child" a child.
+	//
+	// Parameters:
+	//   - pattern (string): The pattern, e.g. "actor://host/workers/*/inbox" or "../*".
+	//   - from (...url.URL): The address the relative patterns start from, e.g. the address of the selecting actor.
+	//
+	// Returns:
+	//   - (Selection): The selection of the matching Addressables, possibly empty.
+	//   - (error): An error if the pattern is invalid or relative without an address to start from.
+	Select(pattern string, from ...url.URL) (Selection, error)
+	// ResolveRelative resolves a path relative to an address.
+	//
+	// Parameters:
+	//   - from (url.URL): The address the path starts from, e.g. the address of the resolving actor.
+	//   - path (string): The relative path, e.g. "../sibling".
+	//
+	// Returns:
+	//   - (common.Addressable): The resolved Addressable.
+	//   - (bool): A boolean indicating whether the resolution was successful.
+	ResolveRelative(from url.URL, path string) (common.Addressable, bool)
 }
 
 // AddressBook is an interface that defines the methods for a catalog.
 type AddressBook interface {
 	Resolver
+	// RegisterWithLease registers the Addressable until its lease is not renewed within the time to live.
+	//
+	// Parameters:
+	//   - addressable (common.Addressable): The Addressable to register.
+	//   - ttl (time.Duration): The time to live of the registration, measured by the clock of the address book.
+	//
+	// Returns:
+	//   - (Lease): The lease to renew or revoke the registration.
+	//   - (error): An error if the registration fails.
+	RegisterWithLease(addressable common.Addressable, ttl time.Duration) (Lease, error)
+	// Watch streams the changes of the registrations matching a pattern.
+	//
+	// The registrations matching when the watch starts are streamed first as added events.
+	//
+	// Parameters:
+	//   - pattern (string): An absolute pattern, as accepted by Select.
+	//
+	// Returns:
+	//   - (Watcher): The watcher streaming the changes.
+	//   - (error): An error if the pattern is invalid.
+	Watch(pattern string) (Watcher, error)
 	// Teardown tears down the catalog and releases any resources.
 	TearDown()
 }
@@ -0,0 +1,90 @@
+package routing
+
+import (
+	"errors"
+	"net/url"
+)
+
+// ErrorNoResolver is returned when no resolver is configured for the scheme of an address.
+var ErrorNoResolver = errors.New("no resolver for scheme")
+
+// Entry is a registration stored in a name service Backend.
+type Entry struct {
+	// Location is the address of the registered actor
+	Location url.URL
+	// Endpoint is where the process owning the actor can be reached, e.g. "localhost:7000"
+	Endpoint string
+	// Metadata carries additional details of the registration
+	Metadata map[string]string
+}
+
+// Address returns the address of the registered actor, an Entry can then be resolved as an Addressable.
+//
+// Returns:
+//   - (url.URL): The address of the registered actor.
+func (e Entry) Address() url.URL {
+	return e.Location
+}
+
+// Backend stores the registrations of a name service, possibly shared by several processes.
+type Backend interface {
+	// Put stores a registration, replacing the registration of the same address.
+	//
+	// Parameters:
+	//   - entry (Entry): The registration to be stored.
+	//
+	// Returns:
+	//   - (error): An error if the registration cannot be stored.
+	Put(entry Entry) error
+	// Delete removes the registration of an address.
+	//
+	// Parameters:
+	//   - address (url.URL): The address to be removed.
+	//
+	// Returns:
+	//   - (error): An error if the registration cannot be removed.
+	Delete(address url.URL) error
+	// Load reads every stored registration.
+	//
+	// Returns:
+	//   - ([]Entry): The stored registrations.
+	//   - (error): An error if the registrations cannot be read.
+	Load() ([]Entry, error)
+	// Changed signals the changes of the stored registrations, made by this or another process.
+	//
+	// Returns:
+	//   - (<-chan struct{}): The channel signalling the changes, nil when the backend never changes by itself.
+	Changed() <-chan struct{}
+	// Close releases the resources of the backend.
+	//
+	// Returns:
+	//   - (error): An error if the resources cannot be released.
+	Close() error
+}
+
+// NameService is a Resolver sharing its registrations through a Backend.
+//
+// Registered Addressables are resolved as such within the registering process, the registrations of the
+// other processes are resolved as Entry values.
+type NameService interface {
+	Resolver
+	// Watch streams the changes of the registrations matching a pattern, including those of the other processes.
+	//
+	// Parameters:
+	//   - pattern (string): An absolute pattern, as accepted by Select.
+	//
+	// Returns:
+	//   - (Watcher): The watcher streaming the changes.
+	//   - (error): An error if the pattern is invalid.
+	Watch(pattern string) (Watcher, error)
+	// Refresh reloads the registrations from the backend.
+	//
+	// Returns:
+	//   - (error): An error if the registrations cannot be read.
+	Refresh() error
+	// Close stops following the backend changes and removes the registrations of this process.
+	//
+	// Returns:
+	//   - (error): An error if the registrations cannot be removed or the backend cannot be closed.
+	Close() error
+}
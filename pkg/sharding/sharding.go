@@ -0,0 +1,154 @@
+package sharding
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/routing"
+)
+
+var staticRegionResolverAssertion RegionOption = (*RegionResolver)(nil)
+
+// ErrorUnsupportedMessage is returned when a shard region receives a message which is not a ShardEnvelope.
+var ErrorUnsupportedMessage = errors.New("unsupported shard region message")
+
+// ErrorNoShardRegion is returned when a message is routed while no shard region joined the coordinator.
+var ErrorNoShardRegion = errors.New("no shard region available")
+
+// ErrorRegionUnreachable is returned when the region owning a shard does not resolve to a transport.
+var ErrorRegionUnreachable = errors.New("shard region unreachable")
+
+// ShardEnvelope carries a message for the entity with the given identity.
+type ShardEnvelope struct {
+	// EntityID is the identity of the entity receiving the payload
+	EntityID string
+	// Payload is the message delivered to the entity
+	Payload any
+}
+
+// HashRing is the interface for the consistent hashing of keys onto members.
+type HashRing interface {
+	// Add adds a member to the ring.
+	//
+	// Parameters:
+	//   - member (string): The member to be added.
+	Add(member string)
+	// Remove removes a member from the ring.
+	//
+	// Parameters:
+	//   - member (string): The member to be removed.
+	Remove(member string)
+	// Locate returns the member owning the key.
+	//
+	// Parameters:
+	//   - key (string): The key to be located.
+	//
+	// Returns:
+	//   - (string): The member owning the key.
+	//   - (bool): A boolean indicating whether the ring has members.
+	Locate(key string) (string, bool)
+	// Members returns the members of the ring.
+	//
+	// Returns:
+	//   - ([]string): The sorted members of the ring.
+	Members() []string
+}
+
+// Coordinator is the interface for the allocation of the shards to the shard regions.
+//
+// The entities are mapped to a fixed number of shards, the shards are mapped to the joined regions by
+// consistent hashing; when regions join or leave, the entities of the moving shards are stopped by their
+// previous region and activated by the new one on their next message.
+//
+// The allocation is kept in memory: the regions joining a coordinator run in the same process.
+type Coordinator interface {
+	// ShardOf returns the shard of the entity.
+	//
+	// Parameters:
+	//   - entityID (string): The identity of the entity.
+	//
+	// Returns:
+	//   - (int): The shard of the entity.
+	ShardOf(entityID string) int
+	// WithOwner calls fn with the address of the region owning the shard, no rebalance happens until fn returns.
+	//
+	// Parameters:
+	//   - shard (int): The shard.
+	//   - fn (func(owner url.URL) error): The function using the address of the owner.
+	//
+	// Returns:
+	//   - (error): The error of fn, or ErrorNoShardRegion when no region joined.
+	WithOwner(shard int, fn func(owner url.URL) error) error
+	// Join adds a region, rebalancing the shards.
+	//
+	// Parameters:
+	//   - region (ShardRegion): The joining region.
+	//
+	// Returns:
+	//   - (error): An error if the region already joined, otherwise nil.
+	Join(region ShardRegion) error
+	// Leave removes a region, rebalancing its shards onto the remaining regions.
+	//
+	// Parameters:
+	//   - region (ShardRegion): The leaving region.
+	Leave(region ShardRegion)
+	// Regions returns the addresses of the joined regions.
+	//
+	// Returns:
+	//   - ([]url.URL): The addresses of the joined regions.
+	Regions() []url.URL
+}
+
+// ShardRegion is the interface for the actors hosting the entities of the shards allocated to them.
+//
+// Regions accept ShardEnvelope messages, delivering the payload to the local entity or forwarding the
+// envelope to the region owning the shard, resolved by address to the transport reaching it.
+type ShardRegion interface {
+	framework.ActorRef
+	// TypeName returns the type of the entities hosted by the region.
+	//
+	// Returns:
+	//   - (string): The type of the entities.
+	TypeName() string
+	// Entities returns the identities of the entities hosted by the region.
+	//
+	// Returns:
+	//   - ([]string): The sorted identities of the hosted entities.
+	Entities() []string
+	// Release stops the entities of the given shards, storing their state.
+	//
+	// Parameters:
+	//   - shards (...int): The shards moving to other regions.
+	Release(shards ...int)
+}
+
+// Settings collects the optional settings of a shard region.
+type Settings struct {
+	// Resolver resolves the addresses of the regions owning the shards, the address book of the system when nil
+	Resolver routing.Resolver
+}
+
+// RegionOption is the interface for the options configuring the shard region itself, besides its actor and its entities.
+type RegionOption interface {
+	framework.ActorOption
+	// ApplyRegion applies the option to the region settings
+	//
+	// Parameters:
+	//   - settings (*Settings): The settings to be updated.
+	ApplyRegion(settings *Settings)
+}
+
+// RegionResolver resolves the addresses of the regions owning the shards, e.g. to the transports reaching them.
+type RegionResolver struct {
+	// Resolver shared by the regions, the region registers in it
+	Resolver routing.Resolver
+}
+
+// Apply leaves the actor configuration untouched.
+func (r RegionResolver) Apply(config *framework.ActorConfig) {}
+
+// ApplyRegion sets the resolver of the region.
+func (r RegionResolver) ApplyRegion(settings *Settings) {
+	settings.Resolver = r.Resolver
+}
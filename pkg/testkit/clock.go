@@ -0,0 +1,219 @@
+package testkit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// VirtualClock is a clock whose time only moves when the test advances it.
+//
+// Timers fire, in expiration order, while the clock is advanced; AfterFunc callbacks run synchronously on the advancing goroutine.
+type VirtualClock interface {
+	framework.Clock
+	// Advance moves the time forward, firing the timers expiring meanwhile.
+	//
+	// Parameters:
+	//   - d (time.Duration): The amount of time to move forward.
+	Advance(d time.Duration)
+	// Set moves the time to the given instant, firing the timers expiring meanwhile; a time in the past is ignored.
+	//
+	// Parameters:
+	//   - to (time.Time): The new current time.
+	Set(to time.Time)
+	// PendingTimers returns the number of timers not fired or stopped yet.
+	//
+	// Returns:
+	//   - (int): The number of pending timers.
+	PendingTimers() int
+	// AwaitTimers waits until at least the given number of timers are pending, e.g. armed by another goroutine.
+	//
+	// Parameters:
+	//   - timers (int): The number of pending timers to wait for.
+	//   - timeout (time.Duration): The maximum real time to wait.
+	//
+	// Returns:
+	//   - (bool): True if the timers are pending, false if the timeout elapsed first.
+	AwaitTimers(timers int, timeout time.Duration) bool
+}
+
+var staticVirtualClockAssertion VirtualClock = (*virtualClock)(nil)
+var staticVirtualTimerAssertion framework.Timer = (*virtualTimer)(nil)
+
+type virtualClock struct {
+	lock *sync.Mutex
+
+	now    time.Time
+	seq    uint64
+	timers map[*virtualTimer]struct{}
+	// armed is closed, then replaced, whenever a timer is armed
+	armed chan struct{}
+}
+
+// NewVirtualClock creates a new virtual clock.
+//
+// Parameters:
+//   - start (time.Time): The initial time of the clock.
+//
+// Returns:
+//   - (VirtualClock): The created VirtualClock instance.
+func NewVirtualClock(start time.Time) VirtualClock {
+	return &virtualClock{
+		lock:   &sync.Mutex{},
+		now:    start,
+		timers: make(map[*virtualTimer]struct{}),
+		armed:  make(chan struct{}),
+	}
+}
+
+// Now returns the current virtual time.
+func (c *virtualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// Since returns the virtual time elapsed since t.
+func (c *virtualClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After sends the virtual time on the returned channel once the duration elapsed.
+func (c *virtualClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a new timer firing once the duration elapsed.
+func (c *virtualClock) NewTimer(d time.Duration) framework.Timer {
+	rv := &virtualTimer{clock: c, ch: make(chan time.Time, 1)}
+	rv.Reset(d)
+	return rv
+}
+
+// AfterFunc calls fn once the duration elapsed.
+func (c *virtualClock) AfterFunc(d time.Duration, fn func()) framework.Timer {
+	rv := &virtualTimer{clock: c, fn: fn}
+	rv.Reset(d)
+	return rv
+}
+
+// Advance moves the time forward, firing the timers expiring meanwhile.
+func (c *virtualClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the time to the given instant, firing the timers expiring meanwhile.
+func (c *virtualClock) Set(to time.Time) {
+	for {
+		c.lock.Lock()
+		next := c.nextExpiring(to)
+		if next == nil {
+			if to.After(c.now) {
+				c.now = to
+			}
+			c.lock.Unlock()
+			return
+		}
+		delete(c.timers, next)
+		if next.when.After(c.now) {
+			c.now = next.when
+		}
+		now := c.now
+		c.lock.Unlock()
+
+		next.fire(now)
+	}
+}
+
+// PendingTimers returns the number of timers not fired or stopped yet.
+func (c *virtualClock) PendingTimers() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.timers)
+}
+
+// AwaitTimers waits until at least the given number of timers are pending.
+func (c *virtualClock) AwaitTimers(timers int, timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		c.lock.Lock()
+		if len(c.timers) >= timers {
+			c.lock.Unlock()
+			return true
+		}
+		armed := c.armed
+		c.lock.Unlock()
+
+		select {
+		case <-armed:
+		case <-deadline.C:
+			return false
+		}
+	}
+}
+
+func (c *virtualClock) nextExpiring(to time.Time) *virtualTimer {
+	var rv *virtualTimer
+	for timer := range c.timers {
+		if timer.when.After(to) {
+			continue
+		}
+		if rv == nil || timer.when.Before(rv.when) || (timer.when.Equal(rv.when) && timer.seq < rv.seq) {
+			rv = timer
+		}
+	}
+	return rv
+}
+
+type virtualTimer struct {
+	clock *virtualClock
+
+	when time.Time
+	seq  uint64
+	ch   chan time.Time
+	fn   func()
+}
+
+// C returns the channel on which the time is delivered, nil for AfterFunc timers.
+func (t *virtualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop prevents the timer from firing.
+func (t *virtualTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	_, active := t.clock.timers[t]
+	delete(t.clock.timers, t)
+	return active
+}
+
+// Reset changes the timer to expire after the duration.
+func (t *virtualTimer) Reset(d time.Duration) bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	_, active := t.clock.timers[t]
+	t.clock.seq++
+	t.seq = t.clock.seq
+	t.when = t.clock.now.Add(d)
+	t.clock.timers[t] = struct{}{}
+	close(t.clock.armed)
+	t.clock.armed = make(chan struct{})
+	return active
+}
+
+func (t *virtualTimer) fire(now time.Time) {
+	if t.fn != nil {
+		t.fn()
+		return
+	}
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
@@ -0,0 +1,188 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/pkg/builders"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type collectorState struct {
+	received []string
+}
+
+func newCollector(t *testing.T, options ...framework.ActorOption) framework.Actor[collectorState] {
+	address, err := url.Parse("actor://collector")
+	assert.NilError(t, err)
+
+	collector, err := builders.NewActor(*address, func(msg framework.Message, self framework.Actor[collectorState]) (collectorState, error) {
+		state := self.State()
+		state.received = append(append([]string(nil), state.received...), fmt.Sprint(msg.Payload()))
+		return state, nil
+	}, collectorState{}, options...)
+	assert.NilError(t, err)
+
+	return collector
+}
+
+func interleaving(t *testing.T, seed uint64) []string {
+	dispatcher := testkit.NewDeterministicDispatcher(seed)
+	collector := newCollector(t, builders.WithDispatcher(dispatcher))
+
+	for i := range 3 {
+		address, err := url.Parse(fmt.Sprintf("actor://forwarder/%d", i))
+		assert.NilError(t, err)
+		forwarder, err := builders.NewActor(*address, func(msg framework.Message, self framework.Actor[echoState]) (echoState, error) {
+			return self.State(), self.Send(msg.Payload(), collector)
+		}, echoState{}, builders.WithDispatcher(dispatcher))
+		assert.NilError(t, err)
+
+		for j := range 4 {
+			assert.NilError(t, forwarder.Deliver(fmt.Sprintf("%d-%d", i, j), collector))
+		}
+	}
+
+	assert.Assert(t, dispatcher.RunUntilIdle() > 0)
+	assert.Equal(t, dispatcher.Pending(), 0)
+	return collector.State().received
+}
+
+func TestVirtualClock(t *testing.T) {
+	t.Log("VirtualClock test suite")
+
+	t.Run("Fire timers in order", func(t *testing.T) {
+		t.Log("Should fire the expired timers in expiration order only when advanced")
+
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := testkit.NewVirtualClock(start)
+
+		fired := make([]string, 0)
+		clock.AfterFunc(2*time.Second, func() { fired = append(fired, "second") })
+		clock.AfterFunc(time.Second, func() { fired = append(fired, "first") })
+		stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+		timer := clock.NewTimer(3 * time.Second)
+		assert.Assert(t, stopped.Stop())
+		assert.Equal(t, clock.PendingTimers(), 3)
+
+		clock.Advance(2 * time.Second)
+		assert.DeepEqual(t, fired, []string{"first", "second"})
+		assert.Equal(t, clock.Since(start), 2*time.Second)
+		select {
+		case <-timer.C():
+			t.Fatal("timer fired before its expiration")
+		default:
+		}
+
+		clock.Advance(time.Second)
+		assert.Equal(t, <-timer.C(), start.Add(3*time.Second))
+		assert.Equal(t, clock.PendingTimers(), 0)
+	})
+
+	t.Run("Await the timers of another goroutine", func(t *testing.T) {
+		t.Log("Should return once another goroutine armed the awaited timers, false when none is armed in time")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		assert.Assert(t, !clock.AwaitTimers(1, 10*time.Millisecond))
+
+		fired := make(chan time.Time, 1)
+		go func() {
+			fired <- <-clock.After(time.Second)
+		}()
+		assert.Assert(t, clock.AwaitTimers(1, testkit.DefaultTimeout))
+		clock.Advance(time.Second)
+		assert.Equal(t, <-fired, time.Unix(1, 0))
+	})
+
+	t.Run("Schedule deliveries", func(t *testing.T) {
+		t.Log("Should deliver the scheduled messages on virtual time")
+
+		clock := testkit.NewVirtualClock(time.Unix(0, 0))
+		dispatcher := testkit.NewDeterministicDispatcher(1)
+		collector := newCollector(t, builders.WithDispatcher(dispatcher), builders.WithClock(clock))
+		scheduler := builders.NewScheduler(clock)
+
+		scheduler.ScheduleOnce(time.Minute, "once", collector, collector)
+		ticks := scheduler.ScheduleRepeatedly(10*time.Second, 20*time.Second, "tick", collector, collector)
+
+		clock.Advance(50 * time.Second)
+		dispatcher.RunUntilIdle()
+		assert.DeepEqual(t, collector.State().received, []string{"tick", "tick", "tick"})
+
+		assert.Assert(t, ticks.Cancel())
+		assert.Assert(t, !ticks.Cancel())
+		clock.Advance(time.Hour)
+		dispatcher.RunUntilIdle()
+		assert.DeepEqual(t, collector.State().received, []string{"tick", "tick", "tick", "once"})
+	})
+}
+
+func TestDeterministicDispatcher(t *testing.T) {
+	t.Log("DeterministicDispatcher test suite")
+
+	t.Run("Reproduce the interleaving", func(t *testing.T) {
+		t.Log("Should interleave the messages the same way for the same seed")
+
+		first := interleaving(t, 42)
+		assert.Equal(t, len(first), 12)
+		assert.DeepEqual(t, interleaving(t, 42), first)
+
+		differs := false
+		for seed := range uint64(10) {
+			if fmt.Sprint(interleaving(t, seed)) != fmt.Sprint(first) {
+				differs = true
+			}
+		}
+		assert.Assert(t, differs)
+	})
+
+	t.Run("Run only on demand", func(t *testing.T) {
+		t.Log("Should process messages and complete the stop only when given turns")
+
+		dispatcher := testkit.NewDeterministicDispatcher(7)
+		collector := newCollector(t, builders.WithDispatcher(dispatcher))
+		probe := testkit.NewTestProbe(t)
+		probe.WatchActor(collector)
+
+		assert.NilError(t, collector.Deliver("hello", collector))
+		assert.Equal(t, dispatcher.Pending(), 1)
+		assert.Equal(t, len(collector.State().received), 0)
+
+		stopCompleted, err := collector.Stop()
+		assert.NilError(t, err)
+		probe.ExpectNoMsg(20 * time.Millisecond)
+
+		dispatcher.RunUntilIdle()
+		<-stopCompleted
+		assert.DeepEqual(t, collector.State().received, []string{"hello"})
+		probe.ExpectTerminated(collector)
+	})
+
+	t.Run("Stop a parent with children", func(t *testing.T) {
+		t.Log("Should stop the parent and its children on the turns given after the stop")
+
+		dispatcher := testkit.NewDeterministicDispatcher(3)
+		parent := newCollector(t, builders.WithDispatcher(dispatcher))
+		child, err := builders.SpawnChild(parent, func(msg framework.Message, self framework.Actor[collectorState]) (collectorState, error) {
+			return self.State(), nil
+		}, collectorState{})
+		assert.NilError(t, err)
+		assert.NilError(t, child.Watch(parent))
+		probe := testkit.NewTestProbe(t)
+		probe.WatchActor(parent)
+
+		stopCompleted, err := parent.Stop()
+		assert.NilError(t, err)
+
+		dispatcher.RunUntilIdle()
+		<-stopCompleted
+		assert.Equal(t, child.Status(), framework.ActorStatusIdle)
+		assert.Equal(t, len(parent.Children()), 0)
+		probe.ExpectTerminated(parent)
+	})
+}
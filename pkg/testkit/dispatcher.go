@@ -0,0 +1,103 @@
+package testkit
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// DeterministicDispatcher is a single-threaded dispatcher running the actors only when the test asks for it.
+//
+// The next actor to run is picked with a seeded random generator, so a given seed always reproduces the same
+// interleaving of messages; stopping an actor completes on one of its following turns, as does the stop of its
+// children.
+type DeterministicDispatcher interface {
+	framework.Dispatcher
+	// RunNext gives one turn to one of the actors with pending messages.
+	//
+	// Returns:
+	//   - (bool): False when no actor had pending messages.
+	RunNext() bool
+	// RunUntilIdle gives turns to the actors until none has pending messages.
+	//
+	// Returns:
+	//   - (int): The number of turns given.
+	RunUntilIdle() int
+	// Pending returns the number of actors waiting for a turn.
+	//
+	// Returns:
+	//   - (int): The number of actors waiting for a turn.
+	Pending() int
+}
+
+var staticDeterministicDispatcherAssertion DeterministicDispatcher = (*deterministicDispatcher)(nil)
+
+type deterministicDispatcher struct {
+	lock *sync.Mutex
+
+	random     *rand.Rand
+	throughput int
+	ready      []framework.Dispatchable
+}
+
+// NewDeterministicDispatcher creates a new deterministic dispatcher.
+//
+// Parameters:
+//   - seed (uint64): The seed of the interleaving.
+//   - throughput (...int): Optional number of messages processed in a turn, 1 by default.
+//
+// Returns:
+//   - (DeterministicDispatcher): The created DeterministicDispatcher instance.
+func NewDeterministicDispatcher(seed uint64, throughput ...int) DeterministicDispatcher {
+	useThroughput := 1
+	if len(throughput) > 0 && throughput[0] > 0 {
+		useThroughput = throughput[0]
+	}
+	return &deterministicDispatcher{
+		lock:       &sync.Mutex{},
+		random:     rand.New(rand.NewPCG(seed, seed)),
+		throughput: useThroughput,
+	}
+}
+
+// Schedule requests a turn for the dispatchable.
+func (d *deterministicDispatcher) Schedule(dispatchable framework.Dispatchable) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.ready = append(d.ready, dispatchable)
+}
+
+// RunNext gives one turn to one of the actors with pending messages.
+func (d *deterministicDispatcher) RunNext() bool {
+	d.lock.Lock()
+	if len(d.ready) == 0 {
+		d.lock.Unlock()
+		return false
+	}
+	idx := d.random.IntN(len(d.ready))
+	next := d.ready[idx]
+	d.ready = append(d.ready[:idx], d.ready[idx+1:]...)
+	d.lock.Unlock()
+
+	if next.Run(d.throughput) {
+		d.Schedule(next)
+	}
+	return true
+}
+
+// RunUntilIdle gives turns to the actors until none has pending messages.
+func (d *deterministicDispatcher) RunUntilIdle() int {
+	rv := 0
+	for d.RunNext() {
+		rv++
+	}
+	return rv
+}
+
+// Pending returns the number of actors waiting for a turn.
+func (d *deterministicDispatcher) Pending() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return len(d.ready)
+}
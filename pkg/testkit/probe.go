@@ -0,0 +1,265 @@
+package testkit
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	f "github.com/morphy76/lang-actor/internal/framework"
+	"github.com/morphy76/lang-actor/pkg/framework"
+)
+
+// DefaultTimeout is the time the expectations wait for a message when no duration is given.
+var DefaultTimeout = 3 * time.Second
+
+// TestProbe is an actor recording the messages it receives, to be used in tests instead of time.Sleep and shared variables.
+type TestProbe interface {
+	framework.ActorRef
+	// ReceiveOne waits for the next message.
+	//
+	// Parameters:
+	//   - within (time.Duration): The maximum time to wait for the message.
+	//
+	// Returns:
+	//   - (framework.Message): The received message.
+	//   - (bool): A boolean indicating whether a message was received in time.
+	ReceiveOne(within time.Duration) (framework.Message, bool)
+	// ExpectMsg fails the test unless the next message payload equals the expected one.
+	//
+	// Parameters:
+	//   - expected (any): The expected payload, compared with reflect.DeepEqual.
+	//   - within (...time.Duration): Optional maximum time to wait, DefaultTimeout by default.
+	//
+	// Returns:
+	//   - (framework.Message): The received message.
+	ExpectMsg(expected any, within ...time.Duration) framework.Message
+	// ExpectNoMsg fails the test if a message is received during the given duration.
+	//
+	// Parameters:
+	//   - within (time.Duration): The time during which no message is expected.
+	ExpectNoMsg(within time.Duration)
+	// FishForMessage discards messages until the matcher accepts one; fails the test when none is accepted in time.
+	//
+	// Parameters:
+	//   - matcher (func(payload any) bool): The function accepting the awaited payload.
+	//   - within (...time.Duration): Optional maximum time to wait, DefaultTimeout by default.
+	//
+	// Returns:
+	//   - (framework.Message): The accepted message.
+	FishForMessage(matcher func(payload any) bool, within ...time.Duration) framework.Message
+	// WatchActor makes the probe a watcher of the given actor.
+	//
+	// Parameters:
+	//   - target (framework.ActorRef): The actor to be watched.
+	WatchActor(target framework.ActorRef)
+	// ExpectTerminated fails the test unless the termination of the given, watched, actor is received.
+	//
+	// Parameters:
+	//   - target (framework.ActorRef): The watched actor.
+	//   - within (...time.Duration): Optional maximum time to wait, DefaultTimeout by default.
+	ExpectTerminated(target framework.ActorRef, within ...time.Duration)
+}
+
+var staticTestProbeAssertion TestProbe = (*testProbe)(nil)
+
+type probeState struct{}
+
+type testProbe struct {
+	framework.Actor[probeState]
+
+	t testing.TB
+
+	lock     *sync.Mutex
+	received []framework.Message
+	signal   chan struct{}
+}
+
+// NewTestProbe creates a new probe actor, stopped when the test completes.
+//
+// Parameters:
+//   - t (testing.TB): The test using the probe, failed by unmet expectations.
+//   - options (...framework.ActorOption): Optional settings of the probe actor.
+//
+// Returns:
+//   - (TestProbe): The created TestProbe instance.
+func NewTestProbe(t testing.TB, options ...framework.ActorOption) TestProbe {
+	t.Helper()
+
+	address, err := url.Parse("actor://testkit/probe/" + uuid.NewString())
+	if err != nil {
+		t.Fatalf("failed to create probe address: %v", err)
+	}
+
+	rv := &testProbe{
+		t: t,
+
+		lock:   &sync.Mutex{},
+		signal: make(chan struct{}, 1),
+	}
+	processingFn := func(msg framework.Message, self framework.Actor[probeState]) (probeState, error) {
+		rv.record(msg)
+		return self.State(), nil
+	}
+
+	useOptions := append([]framework.ActorOption{framework.MailboxConfig{Policy: framework.BackpressurePolicyUnbounded}}, options...)
+	actor, err := f.NewActor(*address, processingFn, probeState{}, useOptions...)
+	if err != nil {
+		t.Fatalf("failed to create probe actor: %v", err)
+	}
+
+	rv.Actor = actor
+	t.Cleanup(func() {
+		if actor.Status() == framework.ActorStatusRunning {
+			if stopCompleted, err := actor.Stop(); err == nil {
+				<-stopCompleted
+			}
+		}
+	})
+
+	return rv
+}
+
+// ReceiveOne waits for the next message.
+func (p *testProbe) ReceiveOne(within time.Duration) (framework.Message, bool) {
+	timer := time.NewTimer(within)
+	defer timer.Stop()
+
+	for {
+		p.lock.Lock()
+		if len(p.received) > 0 {
+			msg := p.received[0]
+			p.received = p.received[1:]
+			p.lock.Unlock()
+			return msg, true
+		}
+		p.lock.Unlock()
+
+		select {
+		case <-p.signal:
+		case <-timer.C:
+			return nil, false
+		}
+	}
+}
+
+// ExpectMsg fails the test unless the next message payload equals the expected one.
+func (p *testProbe) ExpectMsg(expected any, within ...time.Duration) framework.Message {
+	p.t.Helper()
+
+	msg := p.expectOne(fmt.Sprintf("message [%+v]", expected), within...)
+	if !reflect.DeepEqual(msg.Payload(), expected) {
+		p.t.Fatalf("expected message [%+v], received [%+v]", expected, msg.Payload())
+	}
+	return msg
+}
+
+// ExpectNoMsg fails the test if a message is received during the given duration.
+func (p *testProbe) ExpectNoMsg(within time.Duration) {
+	p.t.Helper()
+
+	if msg, ok := p.ReceiveOne(within); ok {
+		p.t.Fatalf("expected no message within [%v], received [%+v]", within, msg.Payload())
+	}
+}
+
+// FishForMessage discards messages until the matcher accepts one.
+func (p *testProbe) FishForMessage(matcher func(payload any) bool, within ...time.Duration) framework.Message {
+	p.t.Helper()
+
+	deadline := time.Now().Add(timeout(within...))
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			p.t.Fatalf("no matching message received within [%v]", timeout(within...))
+			return nil
+		}
+		msg, ok := p.ReceiveOne(remaining)
+		if !ok {
+			p.t.Fatalf("no matching message received within [%v]", timeout(within...))
+			return nil
+		}
+		if matcher(msg.Payload()) {
+			return msg
+		}
+	}
+}
+
+// WatchActor makes the probe a watcher of the given actor.
+func (p *testProbe) WatchActor(target framework.ActorRef) {
+	p.t.Helper()
+
+	if err := target.Watch(p); err != nil {
+		p.t.Fatalf("failed to watch actor [%v]: %v", target.Address(), err)
+	}
+}
+
+// ExpectTerminated fails the test unless the termination of the given actor is received.
+func (p *testProbe) ExpectTerminated(target framework.ActorRef, within ...time.Duration) {
+	p.t.Helper()
+
+	msg := p.expectOne(fmt.Sprintf("termination of [%v]", target.Address()), within...)
+	terminated, ok := msg.Payload().(framework.Terminated)
+	if !ok || terminated.Address != target.Address() {
+		p.t.Fatalf("expected termination of [%v], received [%+v]", target.Address(), msg.Payload())
+	}
+}
+
+// ExpectMsgType fails the test unless the next message payload is of type M.
+//
+// Type Parameters:
+//   - M: The expected type of the payload.
+//
+// Parameters:
+//   - probe (TestProbe): The probe receiving the message.
+//   - within (...time.Duration): Optional maximum time to wait, DefaultTimeout by default.
+//
+// Returns:
+//   - (M): The received payload.
+func ExpectMsgType[M any](probe TestProbe, within ...time.Duration) M {
+	useProbe, ok := probe.(*testProbe)
+	if !ok {
+		panic(fmt.Sprintf("unsupported probe [%T]", probe))
+	}
+	useProbe.t.Helper()
+
+	expected := reflect.TypeFor[M]()
+	msg := useProbe.expectOne(fmt.Sprintf("message of type [%v]", expected), within...)
+	payload, ok := msg.Payload().(M)
+	if !ok {
+		useProbe.t.Fatalf("expected message of type [%v], received [%T]", expected, msg.Payload())
+	}
+	return payload
+}
+
+func (p *testProbe) record(msg framework.Message) {
+	p.lock.Lock()
+	p.received = append(p.received, msg)
+	p.lock.Unlock()
+
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (p *testProbe) expectOne(description string, within ...time.Duration) framework.Message {
+	p.t.Helper()
+
+	msg, ok := p.ReceiveOne(timeout(within...))
+	if !ok {
+		p.t.Fatalf("timeout [%v] waiting for %s", timeout(within...), description)
+	}
+	return msg
+}
+
+func timeout(within ...time.Duration) time.Duration {
+	if len(within) > 0 {
+		return within[0]
+	}
+	return DefaultTimeout
+}
@@ -0,0 +1,85 @@
+package testkit_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/pkg/builders"
+	"github.com/morphy76/lang-actor/pkg/framework"
+	"github.com/morphy76/lang-actor/pkg/testkit"
+)
+
+type echoState struct{}
+
+type greeting struct {
+	Name string
+}
+
+func newEcho(t *testing.T, probe testkit.TestProbe) framework.Actor[echoState] {
+	address, err := url.Parse("actor://echo")
+	assert.NilError(t, err)
+
+	echo, err := builders.NewActor(*address, func(msg framework.Message, self framework.Actor[echoState]) (echoState, error) {
+		return self.State(), self.Send(msg.Payload(), probe)
+	}, echoState{})
+	assert.NilError(t, err)
+
+	return echo
+}
+
+func TestTestProbe(t *testing.T) {
+	t.Log("TestProbe test suite")
+
+	t.Run("Expect messages by value and type", func(t *testing.T) {
+		t.Log("Should match the received messages by value and by type")
+
+		probe := testkit.NewTestProbe(t)
+		echo := newEcho(t, probe)
+
+		assert.NilError(t, echo.Deliver("hello", probe))
+		assert.NilError(t, echo.Deliver(greeting{Name: "world"}, probe))
+
+		msg := probe.ExpectMsg("hello")
+		assert.Equal(t, msg.Sender(), echo.Address())
+		received := testkit.ExpectMsgType[greeting](probe)
+		assert.Equal(t, received.Name, "world")
+		probe.ExpectNoMsg(50 * time.Millisecond)
+	})
+
+	t.Run("Fish for a message", func(t *testing.T) {
+		t.Log("Should discard the messages until the matching one")
+
+		probe := testkit.NewTestProbe(t)
+		echo := newEcho(t, probe)
+
+		for _, word := range []string{"one", "two", "three"} {
+			assert.NilError(t, echo.Deliver(word, probe))
+		}
+
+		msg := probe.FishForMessage(func(payload any) bool {
+			word, ok := payload.(string)
+			return ok && strings.HasPrefix(word, "th")
+		})
+		assert.Equal(t, msg.Payload(), "three")
+		probe.ExpectNoMsg(50 * time.Millisecond)
+	})
+
+	t.Run("Watch for termination", func(t *testing.T) {
+		t.Log("Should receive the termination of the watched actors, including the already stopped ones")
+
+		probe := testkit.NewTestProbe(t)
+		echo := newEcho(t, probe)
+
+		probe.WatchActor(echo)
+		_, err := echo.Stop()
+		assert.NilError(t, err)
+		probe.ExpectTerminated(echo)
+
+		probe.WatchActor(echo)
+		probe.ExpectTerminated(echo)
+	})
+}
@@ -0,0 +1,247 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/morphy76/lang-actor/pkg/common"
+)
+
+// ErrorInvalidTraceparent is returned when a traceparent header cannot be parsed.
+var ErrorInvalidTraceparent = errors.New("invalid traceparent")
+
+const (
+	// TraceparentKey is the metadata key of the W3C traceparent header.
+	TraceparentKey = "traceparent"
+	// TracestateKey is the metadata key of the W3C tracestate header.
+	TracestateKey = "tracestate"
+
+	// AttributeActorAddress is the span attribute holding the address of the actor.
+	AttributeActorAddress = "actor.address"
+	// AttributeMessageType is the span attribute holding the Go type of the message payload.
+	AttributeMessageType = "message.type"
+	// AttributeGraphNode is the span attribute holding the address of the graph node.
+	AttributeGraphNode = "graph.node"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// IsValid reports whether the trace ID is not all zeros.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String returns the lowercase hex encoding of the trace ID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid reports whether the span ID is not all zeros.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// String returns the lowercase hex encoding of the span ID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext is the propagated part of a span, as defined by the W3C trace context.
+type SpanContext struct {
+	// TraceID is the identifier of the trace the span belongs to
+	TraceID TraceID
+	// SpanID is the identifier of the span
+	SpanID SpanID
+	// Sampled reports whether the span is recorded and exported
+	Sampled bool
+	// TraceState is the opaque vendor specific tracestate header
+	TraceState string
+}
+
+// IsValid reports whether both the trace and span IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent returns the W3C traceparent header of the span context.
+//
+// Returns:
+//   - (string): The traceparent header, version 00.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent parses a W3C traceparent header.
+//
+// Parameters:
+//   - header (string): The traceparent header.
+//
+// Returns:
+//   - (SpanContext): The parsed span context.
+//   - (error): An error if the header is malformed, otherwise nil.
+func ParseTraceparent(header string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, fmt.Errorf("%w: [%s]", ErrorInvalidTraceparent, header)
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, fmt.Errorf("%w: [%s]", ErrorInvalidTraceparent, header)
+	}
+
+	var rv SpanContext
+	if err := decodeHex(parts[1], rv.TraceID[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("%w: trace id [%s]", ErrorInvalidTraceparent, parts[1])
+	}
+	if err := decodeHex(parts[2], rv.SpanID[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("%w: span id [%s]", ErrorInvalidTraceparent, parts[2])
+	}
+	var flags [1]byte
+	if err := decodeHex(parts[3], flags[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("%w: flags [%s]", ErrorInvalidTraceparent, parts[3])
+	}
+	if !rv.IsValid() {
+		return SpanContext{}, fmt.Errorf("%w: all zeros id in [%s]", ErrorInvalidTraceparent, header)
+	}
+	rv.Sampled = flags[0]&0x01 == 0x01
+
+	return rv, nil
+}
+
+// Inject writes the span context into the metadata.
+//
+// Parameters:
+//   - sc (SpanContext): The span context to be propagated.
+//   - metadata (common.Metadata): The metadata receiving the traceparent and tracestate entries.
+func Inject(sc SpanContext, metadata common.Metadata) {
+	if !sc.IsValid() || metadata == nil {
+		return
+	}
+	metadata[TraceparentKey] = sc.Traceparent()
+	if sc.TraceState != "" {
+		metadata[TracestateKey] = sc.TraceState
+	} else {
+		delete(metadata, TracestateKey)
+	}
+}
+
+// Extract reads the span context from the metadata.
+//
+// Parameters:
+//   - metadata (common.Metadata): The metadata carrying the traceparent and tracestate entries.
+//
+// Returns:
+//   - (SpanContext): The propagated span context.
+//   - (bool): A boolean indicating whether a valid span context was found.
+func Extract(metadata common.Metadata) (SpanContext, bool) {
+	header, found := metadata[TraceparentKey]
+	if !found {
+		return SpanContext{}, false
+	}
+	rv, err := ParseTraceparent(header)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	rv.TraceState = metadata[TracestateKey]
+	return rv, true
+}
+
+// Attribute is a key/value pair describing a span.
+type Attribute struct {
+	// Key is the name of the attribute
+	Key string
+	// Value is the value of the attribute: string, bool, int, int64 or float64
+	Value any
+}
+
+// SpanData is the immutable snapshot of an ended span, handed to exporters.
+type SpanData struct {
+	// Name of the span
+	Name string
+	// Context of the span
+	Context SpanContext
+	// Parent is the ID of the parent span, zero for root spans
+	Parent SpanID
+	// Start is the time the span started
+	Start time.Time
+	// End is the time the span ended
+	End time.Time
+	// Attributes describing the span
+	Attributes []Attribute
+	// Err is the error the span ended with, if any
+	Err error
+}
+
+// Span is the interface for an in-flight unit of work.
+type Span interface {
+	// Context returns the span context to be propagated to child spans.
+	//
+	// Returns:
+	//   - (SpanContext): The context of the span.
+	Context() SpanContext
+	// SetAttribute sets an attribute on the span.
+	//
+	// Parameters:
+	//   - key (string): The name of the attribute.
+	//   - value (any): The value of the attribute.
+	SetAttribute(key string, value any)
+	// End ends the span.
+	//
+	// Parameters:
+	//   - err (error): The error the unit of work ended with, nil on success.
+	End(err error)
+}
+
+// Tracer is the interface to create spans.
+type Tracer interface {
+	// Start starts a new span.
+	//
+	// Parameters:
+	//   - parent (SpanContext): The parent span context, an invalid one starts a new trace.
+	//   - name (string): The name of the span.
+	//   - attributes (...Attribute): The initial attributes of the span.
+	//
+	// Returns:
+	//   - (Span): The started span.
+	Start(parent SpanContext, name string, attributes ...Attribute) Span
+	// Flush exports the ended spans waiting to be exported.
+	//
+	// Returns:
+	//   - (error): An error if the export fails, otherwise nil.
+	Flush() error
+	// Shutdown flushes the pending spans and releases the tracer resources.
+	//
+	// Returns:
+	//   - (error): An error if the final export fails, otherwise nil.
+	Shutdown() error
+}
+
+// Exporter is the interface to send ended spans to a tracing backend.
+type Exporter interface {
+	// Export sends a batch of ended spans.
+	//
+	// Parameters:
+	//   - spans ([]SpanData): The spans to be exported.
+	//
+	// Returns:
+	//   - (error): An error if the export fails, otherwise nil.
+	Export(spans []SpanData) error
+}
+
+func decodeHex(value string, into []byte) error {
+	if len(value) != 2*len(into) || strings.ToLower(value) != value {
+		return ErrorInvalidTraceparent
+	}
+	_, err := hex.Decode(into, []byte(value))
+	return err
+}
@@ -0,0 +1,62 @@
+package tracing_test
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/morphy76/lang-actor/pkg/common"
+	"github.com/morphy76/lang-actor/pkg/tracing"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceparent(t *testing.T) {
+	t.Log("Traceparent test suite")
+
+	t.Run("Parse and format a valid traceparent", func(t *testing.T) {
+		t.Log("Should parse a valid traceparent and format it back unchanged")
+
+		sc, err := tracing.ParseTraceparent(traceparent)
+		assert.NilError(t, err)
+		assert.Assert(t, sc.IsValid())
+		assert.Assert(t, sc.Sampled)
+		assert.Equal(t, sc.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+		assert.Equal(t, sc.SpanID.String(), "00f067aa0ba902b7")
+		assert.Equal(t, sc.Traceparent(), traceparent)
+	})
+
+	t.Run("Reject malformed traceparents", func(t *testing.T) {
+		t.Log("Should reject traceparents with a wrong layout, uppercase hex or all zeros ids")
+
+		for _, header := range []string{
+			"",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		} {
+			_, err := tracing.ParseTraceparent(header)
+			assert.ErrorIs(t, err, tracing.ErrorInvalidTraceparent)
+		}
+	})
+
+	t.Run("Inject and extract through metadata", func(t *testing.T) {
+		t.Log("Should carry the span context and the tracestate through the message metadata")
+
+		sc, err := tracing.ParseTraceparent(traceparent)
+		assert.NilError(t, err)
+		sc.TraceState = "vendor=value"
+
+		metadata := common.Metadata{}
+		tracing.Inject(sc, metadata)
+		assert.Equal(t, metadata[tracing.TraceparentKey], traceparent)
+
+		extracted, found := tracing.Extract(metadata)
+		assert.Assert(t, found)
+		assert.Equal(t, extracted, sc)
+
+		_, found = tracing.Extract(nil)
+		assert.Assert(t, !found)
+	})
+}